@@ -0,0 +1,172 @@
+package accesskey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/nspcc-dev/neofs-s3-gw/creds/accessbox"
+	"go.uber.org/zap"
+)
+
+// errOwnerKeyRequired is returned by Generate when the request doesn't carry
+// an owner_key to resolve a Box for.
+var errOwnerKeyRequired = errors.New("owner_key is required")
+
+// BoxLookup resolves the accessbox.Box an owner's raw key identifies, so a
+// freshly issued access key can be bound to it.
+type BoxLookup interface {
+	GetBox(ctx context.Context, ownerKey string) (*accessbox.Box, error)
+}
+
+// AdminHandler exposes issuance, rotation and revocation of access keys over
+// a small JSON HTTP surface, meant to be mounted on a separate (operator-only)
+// router next to the public S3 router, not on it.
+type AdminHandler struct {
+	svc   Service
+	boxes BoxLookup
+	log   *zap.Logger
+}
+
+// NewAdminHandler builds an AdminHandler backed by svc, resolving owner keys
+// to boxes via boxes.
+func NewAdminHandler(svc Service, boxes BoxLookup, log *zap.Logger) *AdminHandler {
+	return &AdminHandler{svc: svc, boxes: boxes, log: log}
+}
+
+// Mount registers the admin routes under router.
+func (h *AdminHandler) Mount(router *mux.Router) {
+	router.HandleFunc("/accesskeys", h.List).Methods(http.MethodGet)
+	router.HandleFunc("/accesskeys", h.Generate).Methods(http.MethodPost)
+	router.HandleFunc("/accesskeys/{akid}", h.Get).Methods(http.MethodGet)
+	router.HandleFunc("/accesskeys/{akid}/rotate", h.Rotate).Methods(http.MethodPost)
+	router.HandleFunc("/accesskeys/{akid}/revoke", h.Revoke).Methods(http.MethodPost)
+	router.HandleFunc("/accesskeys/{akid}/enable", h.Enable).Methods(http.MethodPost)
+	router.HandleFunc("/accesskeys/{akid}/disable", h.Disable).Methods(http.MethodPost)
+}
+
+type generateRequest struct {
+	OwnerKey string `json:"owner_key"`
+}
+
+type accessKeyResponse struct {
+	AKID        string `json:"akid"`
+	Secret      string `json:"secret,omitempty"`
+	Status      Status `json:"status"`
+	RotatedFrom string `json:"rotated_from,omitempty"`
+}
+
+func (h *AdminHandler) Generate(w http.ResponseWriter, r *http.Request) {
+	var req generateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.OwnerKey == "" {
+		h.writeError(w, http.StatusBadRequest, errOwnerKeyRequired)
+		return
+	}
+
+	box, err := h.boxes.GetBox(r.Context(), req.OwnerKey)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	key, err := h.svc.Generate(r.Context(), &IssueParams{Box: box, OwnerKey: req.OwnerKey})
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, accessKeyResponse{AKID: key.AKID, Secret: key.Secret, Status: key.Status})
+}
+
+func (h *AdminHandler) Get(w http.ResponseWriter, r *http.Request) {
+	akid := mux.Vars(r)["akid"]
+
+	key, err := h.svc.Get(r.Context(), akid)
+	if err != nil {
+		h.writeErrFor(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, accessKeyResponse{AKID: key.AKID, Status: key.Status, RotatedFrom: key.RotatedFrom})
+}
+
+func (h *AdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	ownerKey := r.URL.Query().Get("owner_key")
+	if ownerKey == "" {
+		h.writeError(w, http.StatusBadRequest, errOwnerKeyRequired)
+		return
+	}
+
+	keys, err := h.svc.List(r.Context(), ownerKey)
+	if err != nil {
+		h.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make([]accessKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		resp = append(resp, accessKeyResponse{AKID: key.AKID, Status: key.Status, RotatedFrom: key.RotatedFrom})
+	}
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	akid := mux.Vars(r)["akid"]
+
+	key, err := h.svc.Rotate(r.Context(), &RotateParams{AKID: akid})
+	if err != nil {
+		h.writeErrFor(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, accessKeyResponse{AKID: key.AKID, Secret: key.Secret, Status: key.Status, RotatedFrom: key.RotatedFrom})
+}
+
+func (h *AdminHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	h.doStatusChange(w, r, h.svc.Revoke)
+}
+
+func (h *AdminHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	h.doStatusChange(w, r, h.svc.Enable)
+}
+
+func (h *AdminHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	h.doStatusChange(w, r, h.svc.Disable)
+}
+
+func (h *AdminHandler) doStatusChange(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, akid string) error) {
+	akid := mux.Vars(r)["akid"]
+
+	if err := fn(r.Context(), akid); err != nil {
+		h.writeErrFor(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminHandler) writeErrFor(w http.ResponseWriter, err error) {
+	if err == ErrNotFound {
+		h.writeError(w, http.StatusNotFound, err)
+		return
+	}
+	h.writeError(w, http.StatusInternalServerError, err)
+}
+
+func (h *AdminHandler) writeError(w http.ResponseWriter, status int, err error) {
+	h.log.Error("accesskey admin request failed", zap.Error(err))
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func (h *AdminHandler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
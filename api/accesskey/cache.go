@@ -0,0 +1,103 @@
+package accesskey
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// defaultCacheSize is the number of entries kept by the in-memory cache
+// fronting the FrostFS-backed Service.
+const defaultCacheSize = 1024
+
+// defaultCacheTTL bounds how long a cached key is trusted before the next
+// lookup goes back to the container. Only keys that were found are cached;
+// a lookup that finds nothing goes to the container every time.
+const defaultCacheTTL = 30 * time.Second
+
+type cacheEntry struct {
+	akid      string
+	key       *AccessKey
+	expiresAt time.Time
+}
+
+// lruCache is a fixed-capacity, TTL-bounded LRU cache of AccessKey lookups.
+// It exists so Authenticate doesn't hit the system container on every
+// request - most traffic reuses the same handful of keys.
+type lruCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+func newLRUCache(capacity int, ttl time.Duration) *lruCache {
+	if capacity <= 0 {
+		capacity = defaultCacheSize
+	}
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return &lruCache{
+		ttl:      ttl,
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached key and whether a live (non-expired) entry was found.
+func (c *lruCache) get(akid string, now time.Time) (*AccessKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[akid]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	if now.After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.items, akid)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.key, true
+}
+
+func (c *lruCache) put(akid string, key *AccessKey, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[akid]; ok {
+		el.Value.(*cacheEntry).key = key
+		el.Value.(*cacheEntry).expiresAt = now.Add(c.ttl)
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&cacheEntry{akid: akid, key: key, expiresAt: now.Add(c.ttl)})
+	c.items[akid] = el
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).akid)
+	}
+}
+
+func (c *lruCache) invalidate(akid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[akid]; ok {
+		c.order.Remove(el)
+		delete(c.items, akid)
+	}
+}
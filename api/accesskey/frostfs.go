@@ -0,0 +1,339 @@
+package accesskey
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neofs-s3-gw/creds/accessbox"
+	"go.uber.org/zap"
+)
+
+// ObjectStore is the minimal FrostFS surface the accesskey service needs: a
+// single system container holding one object per access key, addressed by
+// the AKID attribute.
+type ObjectStore interface {
+	Put(ctx context.Context, attrs map[string]string, payload []byte) (objectID string, err error)
+	Get(ctx context.Context, objectID string) ([]byte, error)
+	SearchByAttribute(ctx context.Context, key, value string) (objectID string, found bool, err error)
+	// ListByAttribute returns every live object id carrying the given
+	// attribute value (there can be more than one, e.g. every access key
+	// issued for the same owner).
+	ListByAttribute(ctx context.Context, key, value string) (objectIDs []string, err error)
+	Delete(ctx context.Context, objectID string) error
+}
+
+// errKeyRevoked is returned by Enable and Disable for a key that has been
+// revoked: revocation can't be undone.
+var errKeyRevoked = errors.New("access key is revoked")
+
+// storedKey is the encrypted-at-rest representation of an AccessKey.
+type storedKey struct {
+	AKID           string         `json:"akid"`
+	Secret         string         `json:"secret"`
+	Box            *accessbox.Box `json:"box"`
+	Status         Status         `json:"status"`
+	CreatedAt      time.Time      `json:"created_at"`
+	RotatedFrom    string         `json:"rotated_from,omitempty"`
+	GraceExpiresAt time.Time      `json:"grace_expires_at,omitempty"`
+	OwnerKey       string         `json:"owner_key,omitempty"`
+}
+
+// frostFSService is the FrostFS-backed Service implementation: each access
+// key is an encrypted object in a dedicated system container, indexed by
+// its AKID attribute, fronted by an in-memory LRU/TTL cache.
+type frostFSService struct {
+	store ObjectStore
+	aead  cipher.AEAD
+	cache *lruCache
+	log   *zap.Logger
+
+	mu     sync.Mutex
+	objIdx map[string]string // AKID -> object ID, refreshed lazily via SearchByAttribute
+}
+
+// NewFrostFSService builds a Service storing keys in store, encrypting
+// secrets at rest with masterKey (must be 16/24/32 bytes, selecting
+// AES-128/192/256-GCM).
+func NewFrostFSService(store ObjectStore, masterKey []byte, log *zap.Logger) (Service, error) {
+	block, err := aes.NewCipher(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid master key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not init AEAD: %w", err)
+	}
+
+	return &frostFSService{
+		store:  store,
+		aead:   aead,
+		cache:  newLRUCache(defaultCacheSize, defaultCacheTTL),
+		log:    log,
+		objIdx: make(map[string]string),
+	}, nil
+}
+
+func (s *frostFSService) Generate(ctx context.Context, p *IssueParams) (*AccessKey, error) {
+	akid, err := randomAKID()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &AccessKey{
+		AKID:      akid,
+		Secret:    secret,
+		Box:       p.Box,
+		Status:    StatusEnabled,
+		CreatedAt: time.Now().UTC(),
+		OwnerKey:  p.OwnerKey,
+	}
+
+	if err = s.persist(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (s *frostFSService) Get(ctx context.Context, akid string) (*AccessKey, error) {
+	if key, ok := s.cache.get(akid, time.Now()); ok {
+		return key, nil
+	}
+
+	key, err := s.load(ctx, akid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.put(akid, key, time.Now())
+	return key, nil
+}
+
+func (s *frostFSService) List(ctx context.Context, ownerKey string) ([]*AccessKey, error) {
+	if ownerKey == "" {
+		return nil, fmt.Errorf("owner key is required")
+	}
+
+	objIDs, err := s.store.ListByAttribute(ctx, "Owner", ownerKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not list access keys: %w", err)
+	}
+
+	keys := make([]*AccessKey, 0, len(objIDs))
+	for _, objID := range objIDs {
+		key, err := s.loadObject(ctx, objID)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *frostFSService) Rotate(ctx context.Context, p *RotateParams) (*AccessKey, error) {
+	old, err := s.Get(ctx, p.AKID)
+	if err != nil {
+		return nil, err
+	}
+
+	gracePeriod := p.GracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = 24 * time.Hour
+	}
+
+	newKey, err := s.Generate(ctx, &IssueParams{Box: old.Box, OwnerKey: old.OwnerKey})
+	if err != nil {
+		return nil, err
+	}
+	newKey.RotatedFrom = old.AKID
+
+	old.GraceExpiresAt = time.Now().UTC().Add(gracePeriod)
+	if err = s.persist(ctx, old); err != nil {
+		return nil, err
+	}
+	s.cache.invalidate(old.AKID)
+
+	if err = s.persist(ctx, newKey); err != nil {
+		return nil, err
+	}
+
+	return newKey, nil
+}
+
+func (s *frostFSService) Revoke(ctx context.Context, akid string) error {
+	return s.setStatus(ctx, akid, StatusRevoked)
+}
+
+func (s *frostFSService) Enable(ctx context.Context, akid string) error {
+	return s.setStatus(ctx, akid, StatusEnabled)
+}
+
+func (s *frostFSService) Disable(ctx context.Context, akid string) error {
+	return s.setStatus(ctx, akid, StatusDisabled)
+}
+
+func (s *frostFSService) setStatus(ctx context.Context, akid string, status Status) error {
+	key, err := s.Get(ctx, akid)
+	if err != nil {
+		return err
+	}
+	if key.Status == StatusRevoked && status != StatusRevoked {
+		return errKeyRevoked
+	}
+	key.Status = status
+
+	if err = s.persist(ctx, key); err != nil {
+		return err
+	}
+	s.cache.invalidate(akid)
+	return nil
+}
+
+// persist writes key as a brand-new FrostFS object (objects are immutable)
+// and then deletes the object it supersedes, if any, so a revoked or
+// rotated-away key doesn't linger as a second, ambiguous match for
+// SearchByAttribute/ListByAttribute once it falls out of objIdx (e.g. after
+// a process restart).
+func (s *frostFSService) persist(ctx context.Context, key *AccessKey) error {
+	plain, err := json.Marshal(storedKey{
+		AKID:           key.AKID,
+		Secret:         key.Secret,
+		Box:            key.Box,
+		Status:         key.Status,
+		CreatedAt:      key.CreatedAt,
+		RotatedFrom:    key.RotatedFrom,
+		GraceExpiresAt: key.GraceExpiresAt,
+		OwnerKey:       key.OwnerKey,
+	})
+	if err != nil {
+		return fmt.Errorf("could not marshal access key: %w", err)
+	}
+
+	ciphertext, err := s.encrypt(plain)
+	if err != nil {
+		return fmt.Errorf("could not encrypt access key: %w", err)
+	}
+
+	s.mu.Lock()
+	oldObjID, hadOld := s.objIdx[key.AKID]
+	s.mu.Unlock()
+
+	attrs := map[string]string{"AKID": key.AKID}
+	if key.OwnerKey != "" {
+		attrs["Owner"] = key.OwnerKey
+	}
+	objID, err := s.store.Put(ctx, attrs, ciphertext)
+	if err != nil {
+		return fmt.Errorf("could not store access key: %w", err)
+	}
+
+	s.mu.Lock()
+	s.objIdx[key.AKID] = objID
+	s.mu.Unlock()
+
+	if hadOld && oldObjID != objID {
+		if err := s.store.Delete(ctx, oldObjID); err != nil {
+			s.log.Warn("accesskey: could not delete superseded object",
+				zap.String("akid", key.AKID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *frostFSService) load(ctx context.Context, akid string) (*AccessKey, error) {
+	s.mu.Lock()
+	objID, ok := s.objIdx[akid]
+	s.mu.Unlock()
+
+	if !ok {
+		var found bool
+		var err error
+		objID, found, err = s.store.SearchByAttribute(ctx, "AKID", akid)
+		if err != nil {
+			return nil, fmt.Errorf("could not search access key: %w", err)
+		}
+		if !found {
+			return nil, ErrNotFound
+		}
+		s.mu.Lock()
+		s.objIdx[akid] = objID
+		s.mu.Unlock()
+	}
+
+	return s.loadObject(ctx, objID)
+}
+
+func (s *frostFSService) loadObject(ctx context.Context, objID string) (*AccessKey, error) {
+	ciphertext, err := s.store.Get(ctx, objID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch access key: %w", err)
+	}
+
+	plain, err := s.decrypt(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt access key: %w", err)
+	}
+
+	var stored storedKey
+	if err = json.Unmarshal(plain, &stored); err != nil {
+		return nil, fmt.Errorf("could not unmarshal access key: %w", err)
+	}
+
+	return &AccessKey{
+		AKID:           stored.AKID,
+		Secret:         stored.Secret,
+		Box:            stored.Box,
+		Status:         stored.Status,
+		CreatedAt:      stored.CreatedAt,
+		RotatedFrom:    stored.RotatedFrom,
+		GraceExpiresAt: stored.GraceExpiresAt,
+		OwnerKey:       stored.OwnerKey,
+	}, nil
+}
+
+func (s *frostFSService) encrypt(plain []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, plain, nil), nil
+}
+
+func (s *frostFSService) decrypt(ciphertext []byte) ([]byte, error) {
+	nonceSize := s.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, data := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	return s.aead.Open(nil, nonce, data, nil)
+}
+
+var akidEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func randomAKID() (string, error) {
+	b := make([]byte, AKIDLength)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return akidEncoding.EncodeToString(b), nil
+}
+
+func randomSecret() (string, error) {
+	b := make([]byte, SecretLength)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return akidEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,247 @@
+package accesskey
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memObject is a single object as memStore sees it: FrostFS objects are
+// immutable and addressed by id, with attributes searched independently of
+// that id, so the fake has to keep every live object around rather than
+// collapsing them into a single per-AKID slot.
+type memObject struct {
+	attrs   map[string]string
+	payload []byte
+}
+
+// memStore is an in-memory ObjectStore used only for tests. It deliberately
+// models the real multi-object-per-attribute shape (several objects can
+// carry the same AKID attribute if a caller fails to clean up after itself)
+// rather than a map keyed by AKID, so tests actually exercise tombstoning.
+type memStore struct {
+	mu      sync.Mutex
+	objects map[string]memObject
+	seq     int
+}
+
+func newMemStore() *memStore {
+	return &memStore{objects: make(map[string]memObject)}
+}
+
+func (s *memStore) Put(_ context.Context, attrs map[string]string, payload []byte) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	id := "obj" + strconv.Itoa(s.seq)
+	s.objects[id] = memObject{attrs: attrs, payload: payload}
+	return id, nil
+}
+
+func (s *memStore) Get(_ context.Context, objectID string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.objects[objectID].payload, nil
+}
+
+func (s *memStore) SearchByAttribute(_ context.Context, key, value string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, obj := range s.objects {
+		if obj.attrs[key] == value {
+			return id, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (s *memStore) ListByAttribute(_ context.Context, key, value string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids []string
+	for id, obj := range s.objects {
+		if obj.attrs[key] == value {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s *memStore) Delete(_ context.Context, objectID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, objectID)
+	return nil
+}
+
+// countByAttribute reports how many live objects carry attrs[key] == value,
+// used to assert that superseded objects are actually cleaned up.
+func (s *memStore) countByAttribute(key, value string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, obj := range s.objects {
+		if obj.attrs[key] == value {
+			n++
+		}
+	}
+	return n
+}
+
+func newTestService(t *testing.T) Service {
+	t.Helper()
+	svc, _ := newTestServiceWithStore(t)
+	return svc
+}
+
+func newTestServiceWithStore(t *testing.T) (Service, *memStore) {
+	t.Helper()
+	store := newMemStore()
+	svc, err := NewFrostFSService(store, make([]byte, 32), zap.NewNop())
+	require.NoError(t, err)
+	return svc, store
+}
+
+func TestGenerateAndGet(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	key, err := svc.Generate(ctx, &IssueParams{})
+	require.NoError(t, err)
+	require.NotEmpty(t, key.AKID)
+	require.NotEmpty(t, key.Secret)
+
+	got, err := svc.Get(ctx, key.AKID)
+	require.NoError(t, err)
+	require.Equal(t, key.Secret, got.Secret)
+}
+
+func TestRotateKeepsOldKeyUsableDuringGracePeriod(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	old, err := svc.Generate(ctx, &IssueParams{})
+	require.NoError(t, err)
+
+	newKey, err := svc.Rotate(ctx, &RotateParams{AKID: old.AKID, GracePeriod: time.Hour})
+	require.NoError(t, err)
+	require.Equal(t, old.AKID, newKey.RotatedFrom)
+
+	reloadedOld, err := svc.Get(ctx, old.AKID)
+	require.NoError(t, err)
+	require.True(t, reloadedOld.Usable(time.Now()), "old key should still be usable within the grace period")
+	require.True(t, reloadedOld.Usable(time.Now().Add(2*time.Hour)) == false, "old key should expire after the grace period")
+
+	require.True(t, newKey.Usable(time.Now()))
+}
+
+func TestRevokeRejectsImmediately(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	key, err := svc.Generate(ctx, &IssueParams{})
+	require.NoError(t, err)
+	require.NoError(t, svc.Revoke(ctx, key.AKID))
+
+	reloaded, err := svc.Get(ctx, key.AKID)
+	require.NoError(t, err)
+	require.False(t, reloaded.Usable(time.Now()))
+}
+
+func TestRevokeDeletesSupersededObject(t *testing.T) {
+	svc, store := newTestServiceWithStore(t)
+	ctx := context.Background()
+
+	key, err := svc.Generate(ctx, &IssueParams{})
+	require.NoError(t, err)
+	require.Equal(t, 1, store.countByAttribute("AKID", key.AKID))
+
+	require.NoError(t, svc.Revoke(ctx, key.AKID))
+
+	// Revoke rewrites the object (new Status); the pre-revoke object must
+	// not be left behind, or SearchByAttribute has two objects to choose
+	// from once objIdx is no longer warm.
+	require.Equal(t, 1, store.countByAttribute("AKID", key.AKID))
+
+	reloaded, err := svc.Get(ctx, key.AKID)
+	require.NoError(t, err)
+	require.False(t, reloaded.Usable(time.Now()))
+}
+
+func TestRotateDeletesSupersededObject(t *testing.T) {
+	svc, store := newTestServiceWithStore(t)
+	ctx := context.Background()
+
+	old, err := svc.Generate(ctx, &IssueParams{})
+	require.NoError(t, err)
+
+	_, err = svc.Rotate(ctx, &RotateParams{AKID: old.AKID, GracePeriod: time.Hour})
+	require.NoError(t, err)
+
+	// Rotate rewrites old with a GraceExpiresAt; only the rewritten object
+	// should still carry old's AKID.
+	require.Equal(t, 1, store.countByAttribute("AKID", old.AKID))
+}
+
+func TestListReturnsKeysForOwner(t *testing.T) {
+	svc := newTestService(t)
+	ctx := context.Background()
+
+	a, err := svc.Generate(ctx, &IssueParams{OwnerKey: "owner-a"})
+	require.NoError(t, err)
+	_, err = svc.Generate(ctx, &IssueParams{OwnerKey: "owner-b"})
+	require.NoError(t, err)
+	b, err := svc.Generate(ctx, &IssueParams{OwnerKey: "owner-a"})
+	require.NoError(t, err)
+
+	keys, err := svc.List(ctx, "owner-a")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	akids := []string{keys[0].AKID, keys[1].AKID}
+	require.ElementsMatch(t, []string{a.AKID, b.AKID}, akids)
+}
+
+func TestListRequiresOwnerKey(t *testing.T) {
+	svc := newTestService(t)
+	_, err := svc.List(context.Background(), "")
+	require.Error(t, err)
+}
+
+func TestGetUnknownKey(t *testing.T) {
+	svc := newTestService(t)
+	_, err := svc.Get(context.Background(), "does-not-exist")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := newLRUCache(2, time.Minute)
+	now := time.Now()
+
+	c.put("a", &AccessKey{AKID: "a"}, now)
+	c.put("b", &AccessKey{AKID: "b"}, now)
+	c.put("c", &AccessKey{AKID: "c"}, now) // evicts "a"
+
+	_, ok := c.get("a", now)
+	require.False(t, ok)
+
+	_, ok = c.get("b", now)
+	require.True(t, ok)
+}
+
+func TestLRUCacheTTLExpiry(t *testing.T) {
+	c := newLRUCache(10, time.Minute)
+	now := time.Now()
+
+	c.put("a", &AccessKey{AKID: "a"}, now)
+	_, ok := c.get("a", now.Add(2*time.Minute))
+	require.False(t, ok, "entry should expire after its TTL")
+}
@@ -0,0 +1,53 @@
+package accesskey
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	namespace = "frostfs_s3"
+	subsystem = "accesskey"
+)
+
+// Metrics records authentication outcomes driven by the accesskey Service:
+// every check against it, and the rejections (revoked, disabled, or past the
+// rotation grace period) that happen before AttachUserAuth would otherwise
+// populate BoxData.
+type Metrics struct {
+	attempts prometheus.Counter
+	rejected *prometheus.CounterVec
+}
+
+// NewMetrics builds and registers the accesskey auth counters.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		attempts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "auth_attempts_total",
+			Help:      "Total number of authentications checked against the access-key service.",
+		}),
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "auth_rejected_total",
+			Help:      "Total number of authentications rejected by the access-key service, by reason.",
+		}, []string{"reason"}),
+	}
+	prometheus.MustRegister(m.attempts, m.rejected)
+	return m
+}
+
+// Unregister removes the counters from the default registry.
+func (m *Metrics) Unregister() {
+	prometheus.Unregister(m.attempts)
+	prometheus.Unregister(m.rejected)
+}
+
+// ObserveAttempt records an authentication checked against the access-key service.
+func (m *Metrics) ObserveAttempt() {
+	m.attempts.Inc()
+}
+
+// ObserveRejection records a rejection, labeled by reason (e.g. "revoked", "disabled").
+func (m *Metrics) ObserveRejection(reason string) {
+	m.rejected.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,93 @@
+// Package accesskey implements issuance, rotation and revocation of S3
+// access-key/secret pairs bound to an accessbox.Box, without requiring an
+// out-of-band neofs-s3-authmate invocation for every change.
+package accesskey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nspcc-dev/neofs-s3-gw/creds/accessbox"
+)
+
+// AKIDLength is the length, in bytes, of an access key ID before base32 encoding.
+const AKIDLength = 8
+
+// SecretLength is the length, in bytes, of an access key secret.
+const SecretLength = 32
+
+// ErrNotFound is returned by Get/Rotate/Revoke/Enable/Disable when the AKID is unknown.
+var ErrNotFound = errors.New("access key not found")
+
+// Status is the lifecycle state of an AccessKey.
+type Status int
+
+// Access key lifecycle states.
+const (
+	StatusEnabled Status = iota
+	StatusDisabled
+	StatusRevoked
+)
+
+// AccessKey is a single S3 access-key/secret pair bound to a Box.
+type AccessKey struct {
+	AKID      string
+	Secret    string
+	Box       *accessbox.Box
+	Status    Status
+	CreatedAt time.Time
+	// RotatedFrom, when set, is the AKID this key replaces. Both the old
+	// and the new AKID stay valid for GracePeriod after rotation so that
+	// in-flight clients aren't cut off mid-request.
+	RotatedFrom string
+	// GraceExpiresAt is non-zero only for a key that is the *old* half of
+	// a rotation pair; after this time it is rejected even if still Enabled.
+	GraceExpiresAt time.Time
+	// OwnerKey is the raw owner key Box was resolved from; see IssueParams.OwnerKey.
+	OwnerKey string
+}
+
+// Expired reports whether the key's rotation grace period has elapsed.
+func (k *AccessKey) Expired(now time.Time) bool {
+	return !k.GraceExpiresAt.IsZero() && now.After(k.GraceExpiresAt)
+}
+
+// Usable reports whether the key may currently be used to authenticate.
+func (k *AccessKey) Usable(now time.Time) bool {
+	return k.Status == StatusEnabled && !k.Expired(now)
+}
+
+// IssueParams configures a new access key.
+type IssueParams struct {
+	Box *accessbox.Box
+	// OwnerKey is the raw owner key the Box was resolved from. It is stored
+	// alongside the key so List can find every key belonging to an owner
+	// without having to introspect the opaque Box itself.
+	OwnerKey string
+}
+
+// RotateParams configures a rotation of an existing access key.
+type RotateParams struct {
+	AKID        string
+	GracePeriod time.Duration
+}
+
+// Service issues, inspects and retires S3 access-key/secret pairs.
+type Service interface {
+	// Generate creates and persists a brand-new access key bound to p.Box.
+	Generate(ctx context.Context, p *IssueParams) (*AccessKey, error)
+	// Get returns the access key identified by akid.
+	Get(ctx context.Context, akid string) (*AccessKey, error)
+	// List returns every access key issued for ownerKey.
+	List(ctx context.Context, ownerKey string) ([]*AccessKey, error)
+	// Rotate issues a new secret for the same Box as akid, keeping akid
+	// valid for p.GracePeriod so callers can switch over without downtime.
+	Rotate(ctx context.Context, p *RotateParams) (*AccessKey, error)
+	// Revoke permanently invalidates akid; Authenticate must reject it immediately.
+	Revoke(ctx context.Context, akid string) error
+	// Enable re-activates a previously disabled (but not revoked) key.
+	Enable(ctx context.Context, akid string) error
+	// Disable temporarily deactivates akid without deleting it.
+	Disable(ctx context.Context, akid string) error
+}
@@ -159,14 +159,15 @@ func (h *handler) CopyObjectHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	params := &layer.CopyObjectParams{
-		SrcObject:   objInfo,
-		ScrBktInfo:  p.BktInfo,
-		DstBktInfo:  dstBktInfo,
-		DstObject:   reqInfo.ObjectName,
-		SrcSize:     objInfo.Size,
-		Header:      metadata,
-		Encryption:  encryptionParams,
-		CopiesNuber: copiesNumber,
+		SrcObject:    objInfo,
+		ScrBktInfo:   p.BktInfo,
+		DstBktInfo:   dstBktInfo,
+		DstObject:    reqInfo.ObjectName,
+		SrcSize:      objInfo.Size,
+		Header:       metadata,
+		Encryption:   encryptionParams,
+		CopiesNuber:  copiesNumber,
+		MetadataOnly: canSkipPayloadCopy(p.BktInfo, dstBktInfo, encryptionParams, objInfo),
 	}
 
 	settings, err := h.obj.GetBucketSettings(r.Context(), dstBktInfo)
@@ -274,3 +275,20 @@ func isValidDirective(directive string) bool {
 	return len(directive) == 0 ||
 		directive == replaceDirective || directive == copyDirective
 }
+
+// canSkipPayloadCopy reports whether CopyObject can perform a metadata-only
+// copy: write a new header object that references the source object's
+// existing payload OID instead of streaming the bytes back through the
+// gateway. This is only safe when the source and destination containers
+// share the same placement (so the payload stays reachable from both) and
+// the destination isn't asking for SSE-C encryption the source doesn't
+// already have, since that would require re-encrypting the payload.
+func canSkipPayloadCopy(srcBktInfo, dstBktInfo *data.BucketInfo, encryption layer.EncryptionParams, srcObjInfo *layer.ObjectInfo) bool {
+	if !srcBktInfo.CID.Equals(dstBktInfo.CID) && srcBktInfo.PlacementPolicy.String() != dstBktInfo.PlacementPolicy.String() {
+		return false
+	}
+	if err := encryption.MatchObjectEncryption(layer.FormEncryptionInfo(srcObjInfo.Headers)); err != nil {
+		return false
+	}
+	return true
+}
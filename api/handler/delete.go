@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"sync"
+
+	"github.com/nspcc-dev/neofs-s3-gw/api"
+	"github.com/nspcc-dev/neofs-s3-gw/api/data"
+	"github.com/nspcc-dev/neofs-s3-gw/api/errors"
+	"github.com/nspcc-dev/neofs-s3-gw/api/layer"
+	"go.uber.org/zap"
+)
+
+// maxDeleteObjects is the upper bound on the number of keys a single
+// MultiObjectDelete request may carry, as defined by the S3 API.
+const maxDeleteObjects = 1000
+
+// defaultDeleteWorkers is used when the gateway config doesn't override
+// the per-request delete concurrency.
+const defaultDeleteWorkers = 20
+
+// DeleteObjectsRequest is the body of `POST /{bucket}?delete`.
+type DeleteObjectsRequest struct {
+	XMLName xml.Name         `xml:"http://s3.amazonaws.com/doc/2006-03-01/ Delete"`
+	Objects []DeleteObjectID `xml:"Object"`
+	Quiet   bool             `xml:"Quiet"`
+}
+
+// DeleteObjectID identifies a single key (and, optionally, version) to delete.
+type DeleteObjectID struct {
+	Key       string `xml:"Key"`
+	VersionID string `xml:"VersionId,omitempty"`
+}
+
+// DeleteObjectsResponse is the XML body returned for MultiObjectDelete.
+type DeleteObjectsResponse struct {
+	XMLName xml.Name            `xml:"DeleteResult"`
+	Deleted []DeletedObject     `xml:"Deleted,omitempty"`
+	Errors  []DeleteObjectError `xml:"Error,omitempty"`
+}
+
+// DeletedObject describes a key that was successfully removed.
+type DeletedObject struct {
+	Key                   string `xml:"Key"`
+	VersionID             string `xml:"VersionId,omitempty"`
+	DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+	DeleteMarkerVersionID string `xml:"DeleteMarkerVersionId,omitempty"`
+}
+
+// DeleteObjectError describes a key that couldn't be removed.
+type DeleteObjectError struct {
+	Key       string `xml:"Key"`
+	VersionID string `xml:"VersionId,omitempty"`
+	Code      string `xml:"Code"`
+	Message   string `xml:"Message"`
+}
+
+// deleteResult is the per-object outcome produced by a worker.
+type deleteResult struct {
+	obj    DeletedObject
+	apiErr errors.Error
+	failed bool
+}
+
+// DeleteMultipleObjectsHandler implements `POST /{bucket}?delete` (MultiObjectDelete).
+//
+// Deletes are fanned out to a bounded worker pool; the response preserves
+// request order regardless of completion order.
+func (h *handler) DeleteMultipleObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		reqInfo = api.GetReqInfo(r.Context())
+		req     DeleteObjectsRequest
+	)
+
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logAndSendError(w, "could not parse delete request", reqInfo, errors.GetAPIError(errors.ErrMalformedXML))
+		return
+	}
+	if len(req.Objects) == 0 {
+		h.logAndSendError(w, "empty delete list", reqInfo, errors.GetAPIError(errors.ErrMalformedXML))
+		return
+	}
+	if len(req.Objects) > maxDeleteObjects {
+		h.logAndSendError(w, "too many keys", reqInfo, errors.GetAPIError(errors.ErrInvalidRequest))
+		return
+	}
+
+	bktInfo, err := h.getBucketAndCheckOwner(r, reqInfo.BucketName)
+	if err != nil {
+		h.logAndSendError(w, "could not get bucket", reqInfo, err)
+		return
+	}
+
+	results := h.deleteObjectsConcurrently(r.Context(), bktInfo, req.Objects)
+	resp := buildDeleteResponse(req.Objects, results, req.Quiet)
+
+	if err = api.EncodeToResponse(w, resp); err != nil {
+		h.logAndSendError(w, "could not encode delete response", reqInfo, err)
+		return
+	}
+
+	for _, res := range results {
+		if !res.failed {
+			h.log.Info("object is deleted", zap.String("bucket", reqInfo.BucketName), zap.String("object", res.obj.Key))
+		}
+	}
+}
+
+// buildDeleteResponse assembles the MultiObjectDelete response from results,
+// indexed to match objects. Errors are always reported, even in quiet mode -
+// only successful deletions are suppressed there.
+func buildDeleteResponse(objects []DeleteObjectID, results []deleteResult, quiet bool) *DeleteObjectsResponse {
+	resp := &DeleteObjectsResponse{}
+	for i, res := range results {
+		if res.failed {
+			resp.Errors = append(resp.Errors, DeleteObjectError{
+				Key:       objects[i].Key,
+				VersionID: objects[i].VersionID,
+				Code:      res.apiErr.Code,
+				Message:   res.apiErr.Description,
+			})
+			continue
+		}
+		if !quiet {
+			resp.Deleted = append(resp.Deleted, res.obj)
+		}
+	}
+	return resp
+}
+
+// deleteObjectsConcurrently deletes objects using a bounded worker pool sized
+// by the gateway's delete concurrency setting, returning results indexed to
+// match the input order.
+func (h *handler) deleteObjectsConcurrently(ctx context.Context, bktInfo *data.BucketInfo, objects []DeleteObjectID) []deleteResult {
+	workers := h.cfg.DeleteObjectsConcurrency
+	if workers <= 0 {
+		workers = defaultDeleteWorkers
+	}
+	if workers > len(objects) {
+		workers = len(objects)
+	}
+
+	results := make([]deleteResult, len(objects))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = h.deleteOneObject(ctx, bktInfo, objects[idx])
+			}
+		}()
+	}
+	for i := range objects {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func (h *handler) deleteOneObject(ctx context.Context, bktInfo *data.BucketInfo, obj DeleteObjectID) deleteResult {
+	objVersion := &layer.ObjectVersion{
+		BktInfo:    bktInfo,
+		ObjectName: obj.Key,
+		VersionID:  obj.VersionID,
+	}
+
+	if locked, err := h.objectIsLocked(ctx, objVersion); err != nil {
+		return deleteResult{apiErr: errors.GetAPIError(errors.ErrInternalError), failed: true}
+	} else if locked {
+		return deleteResult{apiErr: errors.GetAPIError(errors.ErrAccessDenied), failed: true}
+	}
+
+	deletedVersion, err := h.obj.DeleteObject(ctx, &layer.DeleteObjectParams{
+		BktInfo: bktInfo,
+		Object:  objVersion,
+	})
+	if err != nil {
+		apiErr := errors.DefaultIfNotAPIError(err, errors.ErrInternalError)
+		return deleteResult{apiErr: apiErr, failed: true}
+	}
+
+	return deleteResult{obj: DeletedObject{
+		Key:                   obj.Key,
+		VersionID:             obj.VersionID,
+		DeleteMarker:          deletedVersion != nil && deletedVersion.DeleteMarker,
+		DeleteMarkerVersionID: deleteMarkerVersionID(deletedVersion),
+	}}
+}
+
+func deleteMarkerVersionID(v *layer.DeletedObjectInfo) string {
+	if v == nil || !v.DeleteMarker {
+		return ""
+	}
+	return v.VersionID
+}
+
+// objectIsLocked reports whether the object version is protected by an
+// active object-lock retention period or legal hold.
+func (h *handler) objectIsLocked(ctx context.Context, objVersion *layer.ObjectVersion) (bool, error) {
+	lock, err := h.obj.GetLockInfo(ctx, objVersion)
+	if err != nil {
+		if errors.IsS3Error(err, errors.ErrNoSuchKey) {
+			return false, nil
+		}
+		return false, err
+	}
+	if lock == nil {
+		return false, nil
+	}
+	return lock.IsLocked(), nil
+}
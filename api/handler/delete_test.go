@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-s3-gw/api/data"
+	"github.com/nspcc-dev/neofs-s3-gw/api/errors"
+	"github.com/nspcc-dev/neofs-s3-gw/api/layer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeObjectLayer overrides only the methods deleteObjectsConcurrently
+// exercises; everything else falls through to the embedded nil
+// layer.Client and would panic if exercised.
+type fakeObjectLayer struct {
+	layer.Client
+	deleteObjectFn func(ctx context.Context, p *layer.DeleteObjectParams) (*layer.DeletedObjectInfo, error)
+	lockInfoFn     func(ctx context.Context, objVersion *layer.ObjectVersion) (*data.LockInfo, error)
+}
+
+func (f *fakeObjectLayer) DeleteObject(ctx context.Context, p *layer.DeleteObjectParams) (*layer.DeletedObjectInfo, error) {
+	return f.deleteObjectFn(ctx, p)
+}
+
+func (f *fakeObjectLayer) GetLockInfo(ctx context.Context, objVersion *layer.ObjectVersion) (*data.LockInfo, error) {
+	if f.lockInfoFn == nil {
+		return nil, nil
+	}
+	return f.lockInfoFn(ctx, objVersion)
+}
+
+func TestDeleteObjectsConcurrentlyPreservesOrderForMixedOutcomes(t *testing.T) {
+	fake := &fakeObjectLayer{
+		lockInfoFn: func(_ context.Context, ov *layer.ObjectVersion) (*data.LockInfo, error) {
+			if ov.ObjectName == "locked.txt" {
+				lock := data.NewLockInfo()
+				lock.SetLegalHold(true)
+				return lock, nil
+			}
+			return nil, nil
+		},
+		deleteObjectFn: func(_ context.Context, p *layer.DeleteObjectParams) (*layer.DeletedObjectInfo, error) {
+			switch p.Object.ObjectName {
+			case "ok.txt":
+				return &layer.DeletedObjectInfo{}, nil
+			case "broken.txt":
+				return nil, errors.GetAPIError(errors.ErrInternalError)
+			default:
+				t.Fatalf("unexpected delete for %q", p.Object.ObjectName)
+				return nil, nil
+			}
+		},
+	}
+	h := &handler{obj: fake, cfg: Config{DeleteObjectsConcurrency: 2}, log: zap.NewNop()}
+
+	objects := []DeleteObjectID{{Key: "ok.txt"}, {Key: "locked.txt"}, {Key: "broken.txt"}}
+	results := h.deleteObjectsConcurrently(context.Background(), &data.BucketInfo{}, objects)
+	require.Len(t, results, 3)
+
+	require.False(t, results[0].failed)
+	require.Equal(t, "ok.txt", results[0].obj.Key)
+
+	// A locked object is reported as AccessDenied without DeleteObject ever
+	// being called for it, and without affecting the other objects' results.
+	require.True(t, results[1].failed)
+	require.Equal(t, errors.GetAPIError(errors.ErrAccessDenied).Code, results[1].apiErr.Code)
+
+	require.True(t, results[2].failed)
+	require.Equal(t, errors.GetAPIError(errors.ErrInternalError).Code, results[2].apiErr.Code)
+}
+
+func TestBuildDeleteResponseQuietModeSuppressesOnlySuccesses(t *testing.T) {
+	objects := []DeleteObjectID{{Key: "a"}, {Key: "b"}}
+	results := []deleteResult{
+		{obj: DeletedObject{Key: "a"}},
+		{apiErr: errors.GetAPIError(errors.ErrAccessDenied), failed: true},
+	}
+
+	quiet := buildDeleteResponse(objects, results, true)
+	require.Empty(t, quiet.Deleted)
+	require.Len(t, quiet.Errors, 1)
+	require.Equal(t, "b", quiet.Errors[0].Key)
+
+	verbose := buildDeleteResponse(objects, results, false)
+	require.Len(t, verbose.Deleted, 1)
+	require.Equal(t, "a", verbose.Deleted[0].Key)
+	require.Len(t, verbose.Errors, 1)
+}
+
+func TestDeleteMarkerVersionID(t *testing.T) {
+	require.Equal(t, "", deleteMarkerVersionID(nil))
+
+	require.Equal(t, "v2", deleteMarkerVersionID(&layer.DeletedObjectInfo{
+		DeleteMarker: true,
+		VersionID:    "v2",
+	}))
+
+	require.Equal(t, "", deleteMarkerVersionID(&layer.DeletedObjectInfo{
+		DeleteMarker: false,
+		VersionID:    "v2",
+	}))
+}
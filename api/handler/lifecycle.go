@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/nspcc-dev/neofs-s3-gw/api"
+	"github.com/nspcc-dev/neofs-s3-gw/api/errors"
+	"github.com/nspcc-dev/neofs-s3-gw/api/layer"
+	"go.uber.org/zap"
+)
+
+// LifecycleConfiguration is the S3 `PutBucketLifecycleConfiguration` XML body.
+type LifecycleConfiguration struct {
+	XMLName xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LifecycleConfiguration"`
+	Rules   []LifecycleRule `xml:"Rule"`
+}
+
+// LifecycleRule is a single lifecycle rule.
+type LifecycleRule struct {
+	ID                             string                          `xml:"ID,omitempty"`
+	Status                         string                          `xml:"Status"`
+	Filter                         *LifecycleFilter                `xml:"Filter,omitempty"`
+	Expiration                     *LifecycleExpiration            `xml:"Expiration,omitempty"`
+	NoncurrentVersionExpiration    *NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	AbortIncompleteMultipartUpload *AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+}
+
+// LifecycleFilter narrows a rule to a subset of objects.
+type LifecycleFilter struct {
+	Prefix                string              `xml:"Prefix,omitempty"`
+	Tag                   *LifecycleTag       `xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan *uint64             `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    *uint64             `xml:"ObjectSizeLessThan,omitempty"`
+	And                   *LifecycleFilterAnd `xml:"And,omitempty"`
+}
+
+// LifecycleFilterAnd combines several filter conditions (all must match).
+type LifecycleFilterAnd struct {
+	Prefix                string         `xml:"Prefix,omitempty"`
+	Tags                  []LifecycleTag `xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan *uint64        `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    *uint64        `xml:"ObjectSizeLessThan,omitempty"`
+}
+
+// LifecycleTag is a single key/value tag condition.
+type LifecycleTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// LifecycleExpiration describes when current object versions expire.
+type LifecycleExpiration struct {
+	Days                      *int   `xml:"Days,omitempty"`
+	Date                      string `xml:"Date,omitempty"`
+	ExpiredObjectDeleteMarker *bool  `xml:"ExpiredObjectDeleteMarker,omitempty"`
+}
+
+// NoncurrentVersionExpiration describes when non-current versions expire.
+type NoncurrentVersionExpiration struct {
+	NoncurrentDays int `xml:"NoncurrentDays"`
+}
+
+// AbortIncompleteMultipartUpload describes when stale multipart uploads are aborted.
+type AbortIncompleteMultipartUpload struct {
+	DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+}
+
+// PutBucketLifecycleHandler implements `PUT /{bucket}?lifecycle`.
+func (h *handler) PutBucketLifecycleHandler(w http.ResponseWriter, r *http.Request) {
+	reqInfo := api.GetReqInfo(r.Context())
+
+	var cfg LifecycleConfiguration
+	if err := xml.NewDecoder(r.Body).Decode(&cfg); err != nil {
+		h.logAndSendError(w, "could not parse lifecycle configuration", reqInfo, errors.GetAPIError(errors.ErrMalformedXML))
+		return
+	}
+
+	for _, rule := range cfg.Rules {
+		if rule.Status != "Enabled" && rule.Status != "Disabled" {
+			h.logAndSendError(w, "invalid rule status", reqInfo, errors.GetAPIError(errors.ErrInvalidRequest))
+			return
+		}
+	}
+
+	bktInfo, err := h.getBucketAndCheckOwner(r, reqInfo.BucketName)
+	if err != nil {
+		h.logAndSendError(w, "could not get bucket", reqInfo, err)
+		return
+	}
+
+	if err = h.obj.PutBucketLifecycleConfiguration(r.Context(), &layer.PutLifecycleParams{
+		BktInfo: bktInfo,
+		Rules:   cfg.Rules,
+	}); err != nil {
+		h.logAndSendError(w, "could not put lifecycle configuration", reqInfo, err)
+		return
+	}
+
+	h.log.Info("lifecycle configuration is updated", zap.String("bucket", reqInfo.BucketName), zap.Int("rules", len(cfg.Rules)))
+
+	api.WriteSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketLifecycleHandler implements `GET /{bucket}?lifecycle`.
+func (h *handler) GetBucketLifecycleHandler(w http.ResponseWriter, r *http.Request) {
+	reqInfo := api.GetReqInfo(r.Context())
+
+	bktInfo, err := h.getBucketAndCheckOwner(r, reqInfo.BucketName)
+	if err != nil {
+		h.logAndSendError(w, "could not get bucket", reqInfo, err)
+		return
+	}
+
+	cfg, err := h.obj.GetBucketLifecycleConfiguration(r.Context(), bktInfo)
+	if err != nil {
+		h.logAndSendError(w, "could not get lifecycle configuration", reqInfo, err)
+		return
+	}
+	if cfg == nil || len(cfg.Rules) == 0 {
+		h.logAndSendError(w, "lifecycle configuration not found", reqInfo, errors.GetAPIError(errors.ErrNoSuchLifecycleConfiguration))
+		return
+	}
+
+	if err = api.EncodeToResponse(w, cfg); err != nil {
+		h.logAndSendError(w, "could not encode lifecycle configuration", reqInfo, err)
+		return
+	}
+}
+
+// DeleteBucketLifecycleHandler implements `DELETE /{bucket}?lifecycle`.
+func (h *handler) DeleteBucketLifecycleHandler(w http.ResponseWriter, r *http.Request) {
+	reqInfo := api.GetReqInfo(r.Context())
+
+	bktInfo, err := h.getBucketAndCheckOwner(r, reqInfo.BucketName)
+	if err != nil {
+		h.logAndSendError(w, "could not get bucket", reqInfo, err)
+		return
+	}
+
+	if err = h.obj.DeleteBucketLifecycleConfiguration(r.Context(), bktInfo); err != nil {
+		h.logAndSendError(w, "could not delete lifecycle configuration", reqInfo, err)
+		return
+	}
+
+	h.log.Info("lifecycle configuration is deleted", zap.String("bucket", reqInfo.BucketName))
+
+	api.WriteSuccessResponseHeadersOnly(w)
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/nspcc-dev/neofs-s3-gw/api"
+	"github.com/nspcc-dev/neofs-s3-gw/api/errors"
+	"github.com/nspcc-dev/neofs-s3-gw/api/layer"
+	"go.uber.org/zap"
+)
+
+// compression codecs accepted in InputSerialization.
+const (
+	compressionNone  = "NONE"
+	compressionGZIP  = "GZIP"
+	compressionBZIP2 = "BZIP2"
+)
+
+// selectObjectContentArgs mirrors the AWS SelectObjectContentRequest XML body.
+type selectObjectContentArgs struct {
+	XMLName             xml.Name            `xml:"SelectObjectContentRequest"`
+	Expression          string              `xml:"Expression"`
+	ExpressionType      string              `xml:"ExpressionType"`
+	InputSerialization  inputSerialization  `xml:"InputSerialization"`
+	OutputSerialization outputSerialization `xml:"OutputSerialization"`
+	RequestProgress     *requestProgress    `xml:"RequestProgress"`
+}
+
+type requestProgress struct {
+	Enabled bool `xml:"Enabled"`
+}
+
+type inputSerialization struct {
+	CompressionType string     `xml:"CompressionType"`
+	CSV             *csvInput  `xml:"CSV"`
+	JSON            *jsonInput `xml:"JSON"`
+	Parquet         *struct{}  `xml:"Parquet"`
+}
+
+type csvInput struct {
+	FileHeaderInfo             string `xml:"FileHeaderInfo"`
+	FieldDelimiter             string `xml:"FieldDelimiter"`
+	RecordDelimiter            string `xml:"RecordDelimiter"`
+	QuoteCharacter             string `xml:"QuoteCharacter"`
+	AllowQuotedRecordDelimiter bool   `xml:"AllowQuotedRecordDelimiter"`
+}
+
+type jsonInput struct {
+	Type string `xml:"Type"` // DOCUMENT or LINES
+}
+
+type outputSerialization struct {
+	CSV  *csvOutput  `xml:"CSV"`
+	JSON *jsonOutput `xml:"JSON"`
+}
+
+type csvOutput struct {
+	FieldDelimiter  string `xml:"FieldDelimiter"`
+	RecordDelimiter string `xml:"RecordDelimiter"`
+	QuoteFields     string `xml:"QuoteFields"`
+}
+
+type jsonOutput struct {
+	RecordDelimiter string `xml:"RecordDelimiter"`
+}
+
+// maxRecordsPerMessage bounds how many evaluated records are buffered into a
+// single event-stream Records message before it is flushed to the client.
+const maxRecordsPerMessage = 500
+
+// SelectObjectContentHandler implements the S3 `POST /{bucket}/{object}?select&select-type=2` operation.
+//
+// It streams the object through h.obj.GetObject, evaluates a minimal SQL
+// subset against each record, and writes the result back using the AWS
+// event-stream binary framing.
+func (h *handler) SelectObjectContentHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		reqInfo = api.GetReqInfo(r.Context())
+		args    = &selectObjectContentArgs{}
+	)
+
+	if err := xml.NewDecoder(r.Body).Decode(args); err != nil {
+		h.logAndSendError(w, "could not parse select request", reqInfo, errors.GetAPIError(errors.ErrMalformedXML))
+		return
+	}
+
+	if args.ExpressionType != "SQL" {
+		h.logAndSendError(w, "unsupported expression type", reqInfo, errors.GetAPIError(errors.ErrInvalidRequest))
+		return
+	}
+
+	if args.InputSerialization.Parquet != nil {
+		h.logAndSendError(w, "parquet input is not supported yet", reqInfo, errors.GetAPIError(errors.ErrNotImplemented))
+		return
+	}
+
+	query, err := parseSelectQuery(args.Expression)
+	if err != nil {
+		h.logAndSendError(w, "could not parse select expression", reqInfo, errors.GetAPIError(errors.ErrInvalidRequest), zap.Error(err))
+		return
+	}
+
+	bktInfo, err := h.getBucketAndCheckOwner(r, reqInfo.BucketName)
+	if err != nil {
+		h.logAndSendError(w, "could not get bucket", reqInfo, err)
+		return
+	}
+
+	objInfo, err := h.obj.GetObjectInfo(r.Context(), &layer.HeadObjectParams{
+		BktInfo: bktInfo,
+		Object:  reqInfo.ObjectName,
+	})
+	if err != nil {
+		h.logAndSendError(w, "could not find object", reqInfo, err)
+		return
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		getErr := h.obj.GetObject(r.Context(), &layer.GetObjectParams{
+			ObjectInfo: objInfo,
+			Writer:     pw,
+		})
+		_ = pw.CloseWithError(getErr)
+	}()
+
+	src, err := decompress(pr, args.InputSerialization.CompressionType)
+	if err != nil {
+		h.logAndSendError(w, "unsupported compression type", reqInfo, errors.GetAPIError(errors.ErrInvalidRequest))
+		return
+	}
+
+	records, err := newRecordReader(src, args.InputSerialization)
+	if err != nil {
+		h.logAndSendError(w, "could not create record reader", reqInfo, errors.GetAPIError(errors.ErrInvalidRequest), zap.Error(err))
+		return
+	}
+
+	w.Header().Set(api.ContentType, "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+
+	enc := newRecordEncoder(args.OutputSerialization)
+	framer := newEventStreamFramer(w)
+
+	var (
+		batch                        [][]byte
+		bytesScanned, bytesProcessed int64
+		processed                    int64
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		payload := joinRecords(batch)
+		batch = batch[:0]
+		return framer.WriteRecords(payload)
+	}
+
+	for {
+		rec, raw, readErr := records.Next()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			h.log.Error("select: failed reading record", zap.Error(readErr))
+			break
+		}
+		bytesScanned += int64(len(raw))
+
+		matched, evalErr := query.Eval(rec)
+		if evalErr != nil {
+			h.log.Error("select: failed evaluating record", zap.Error(evalErr))
+			continue
+		}
+		if !matched {
+			continue
+		}
+
+		out, encErr := enc.Encode(rec, query)
+		if encErr != nil {
+			h.log.Error("select: failed encoding record", zap.Error(encErr))
+			continue
+		}
+		processed++
+		bytesProcessed += int64(len(out))
+		batch = append(batch, out)
+
+		if query.Limit > 0 && processed >= int64(query.Limit) {
+			break
+		}
+		if len(batch) >= maxRecordsPerMessage {
+			if err := flush(); err != nil {
+				h.log.Error("select: failed writing records message", zap.Error(err))
+				return
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		h.log.Error("select: failed writing final records message", zap.Error(err))
+		return
+	}
+
+	if args.RequestProgress != nil && args.RequestProgress.Enabled {
+		if err := framer.WriteProgress(bytesScanned, bytesProcessed); err != nil {
+			h.log.Error("select: failed writing progress message", zap.Error(err))
+			return
+		}
+	}
+
+	if err := framer.WriteStats(bytesScanned, bytesProcessed); err != nil {
+		h.log.Error("select: failed writing stats message", zap.Error(err))
+		return
+	}
+
+	if err := framer.WriteEnd(); err != nil {
+		h.log.Error("select: failed writing end message", zap.Error(err))
+	}
+}
+
+// decompress wraps src with the decompressor matching compressionType.
+func decompress(src io.Reader, compressionType string) (io.Reader, error) {
+	switch compressionType {
+	case "", compressionNone:
+		return bufio.NewReader(src), nil
+	case compressionGZIP:
+		return gzip.NewReader(src)
+	case compressionBZIP2:
+		return bzip2.NewReader(src), nil
+	default:
+		return nil, errors.GetAPIError(errors.ErrInvalidRequest)
+	}
+}
+
+func joinRecords(batch [][]byte) []byte {
+	size := 0
+	for _, b := range batch {
+		size += len(b)
+	}
+	out := make([]byte, 0, size)
+	for _, b := range batch {
+		out = append(out, b...)
+	}
+	return out
+}
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+	"strings"
+)
+
+// recordReader yields successive input records together with a re-serialized
+// form of each (CSV fields joined by commas, JSON re-marshaled), whose length
+// feeds the Stats `BytesScanned` counter.
+type recordReader interface {
+	Next() (rec *record, raw []byte, err error)
+}
+
+// newRecordReader builds a recordReader for the requested InputSerialization.
+func newRecordReader(src io.Reader, in inputSerialization) (recordReader, error) {
+	switch {
+	case in.JSON != nil:
+		return newJSONRecordReader(src, in.JSON), nil
+	case in.CSV != nil:
+		return newCSVRecordReader(src, in.CSV), nil
+	default:
+		// AWS defaults to CSV when nothing more specific is set.
+		return newCSVRecordReader(src, &csvInput{}), nil
+	}
+}
+
+type csvRecordReader struct {
+	r         *csv.Reader
+	header    []string
+	hasHeader bool
+}
+
+func newCSVRecordReader(src io.Reader, in *csvInput) *csvRecordReader {
+	r := csv.NewReader(src)
+	r.FieldsPerRecord = -1
+	if in.FieldDelimiter != "" {
+		r.Comma = []rune(in.FieldDelimiter)[0]
+	}
+	return &csvRecordReader{r: r, hasHeader: strings.EqualFold(in.FileHeaderInfo, "USE")}
+}
+
+func (c *csvRecordReader) Next() (*record, []byte, error) {
+	fields, err := c.r.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.hasHeader && c.header == nil {
+		c.header = fields
+		fields, err = c.r.Read()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	raw := []byte(strings.Join(fields, ","))
+	return &record{values: fields, header: c.header}, raw, nil
+}
+
+type jsonRecordReader struct {
+	dec *json.Decoder
+}
+
+func newJSONRecordReader(src io.Reader, _ *jsonInput) *jsonRecordReader {
+	return &jsonRecordReader{dec: json.NewDecoder(bufio.NewReader(src))}
+}
+
+func (j *jsonRecordReader) Next() (*record, []byte, error) {
+	var doc map[string]interface{}
+	if err := j.dec.Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+	raw, _ := json.Marshal(doc)
+	return &record{doc: doc}, raw, nil
+}
+
+// recordEncoder renders a matched record into the requested OutputSerialization.
+type recordEncoder struct {
+	out outputSerialization
+}
+
+func newRecordEncoder(out outputSerialization) *recordEncoder {
+	return &recordEncoder{out: out}
+}
+
+func (e *recordEncoder) Encode(r *record, q *selectQuery) ([]byte, error) {
+	values, names, err := e.project(r, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.out.JSON != nil {
+		doc := make(map[string]interface{}, len(values))
+		for i, v := range values {
+			doc[names[i]] = v
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	}
+
+	// default / CSV output.
+	delim := ","
+	if e.out.CSV != nil && e.out.CSV.FieldDelimiter != "" {
+		delim = e.out.CSV.FieldDelimiter
+	}
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = fmt.Sprint(v)
+	}
+	return []byte(strings.Join(strs, delim) + "\n"), nil
+}
+
+func (e *recordEncoder) project(r *record, q *selectQuery) ([]interface{}, []string, error) {
+	if q.CountAll {
+		return []interface{}{int64(1)}, []string{"_1"}, nil
+	}
+	if q.Star {
+		if r.doc != nil {
+			// Go randomizes map iteration order; sort the field names so
+			// CSV/default output (which has no header row) puts the same
+			// field in the same column on every call.
+			names := make([]string, 0, len(r.doc))
+			for k := range r.doc {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+
+			values := make([]interface{}, len(names))
+			for i, k := range names {
+				values[i] = r.doc[k]
+			}
+			return values, names, nil
+		}
+		values := make([]interface{}, len(r.values))
+		names := make([]string, len(r.values))
+		for i, v := range r.values {
+			values[i] = v
+			names[i] = fmt.Sprintf("_%d", i+1)
+		}
+		return values, names, nil
+	}
+
+	values := make([]interface{}, len(q.Columns))
+	names := make([]string, len(q.Columns))
+	for i, col := range q.Columns {
+		v, err := col.Expr.eval(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i] = v
+		if col.Alias != "" {
+			names[i] = col.Alias
+		} else {
+			names[i] = fmt.Sprintf("_%d", i+1)
+		}
+	}
+	return values, names, nil
+}
+
+// eventStreamFramer writes AWS event-stream binary framed messages:
+//
+//	total-length(4B) | header-length(4B) | prelude-crc(4B) | headers | payload | message-crc(4B)
+type eventStreamFramer struct {
+	w io.Writer
+}
+
+func newEventStreamFramer(w io.Writer) *eventStreamFramer {
+	return &eventStreamFramer{w: w}
+}
+
+func (f *eventStreamFramer) WriteRecords(payload []byte) error {
+	return f.writeMessage(map[string]string{
+		":message-type": "event",
+		":event-type":   "Records",
+		":content-type": "application/octet-stream",
+	}, payload)
+}
+
+func (f *eventStreamFramer) WriteStats(bytesScanned, bytesProcessed int64) error {
+	payload := fmt.Sprintf(
+		`<?xml version="1.0" encoding="UTF-8"?><Stats><BytesScanned>%d</BytesScanned><BytesProcessed>%d</BytesProcessed><BytesReturned>%d</BytesReturned></Stats>`,
+		bytesScanned, bytesProcessed, bytesProcessed)
+	return f.writeMessage(map[string]string{
+		":message-type": "event",
+		":event-type":   "Stats",
+		":content-type": "text/xml",
+	}, []byte(payload))
+}
+
+func (f *eventStreamFramer) WriteProgress(bytesScanned, bytesProcessed int64) error {
+	payload := fmt.Sprintf(
+		`<?xml version="1.0" encoding="UTF-8"?><Progress><BytesScanned>%d</BytesScanned><BytesProcessed>%d</BytesProcessed><BytesReturned>%d</BytesReturned></Progress>`,
+		bytesScanned, bytesProcessed, bytesProcessed)
+	return f.writeMessage(map[string]string{
+		":message-type": "event",
+		":event-type":   "Progress",
+		":content-type": "text/xml",
+	}, []byte(payload))
+}
+
+func (f *eventStreamFramer) WriteEnd() error {
+	return f.writeMessage(map[string]string{
+		":message-type": "event",
+		":event-type":   "End",
+	}, nil)
+}
+
+func (f *eventStreamFramer) writeMessage(headers map[string]string, payload []byte) error {
+	var headerBuf bytes.Buffer
+	for _, name := range orderedHeaderNames(headers) {
+		val := headers[name]
+		headerBuf.WriteByte(byte(len(name)))
+		headerBuf.WriteString(name)
+		headerBuf.WriteByte(7) // header value type: string
+		writeUint16(&headerBuf, uint16(len(val)))
+		headerBuf.WriteString(val)
+	}
+
+	headerLen := uint32(headerBuf.Len())
+	totalLen := headerLen + uint32(len(payload)) + 16 // +4 total, +4 header-len, +4 prelude-crc, +4 message-crc
+
+	var prelude bytes.Buffer
+	writeUint32(&prelude, totalLen)
+	writeUint32(&prelude, headerLen)
+	preludeCRC := crc32.ChecksumIEEE(prelude.Bytes())
+
+	var msg bytes.Buffer
+	msg.Write(prelude.Bytes())
+	writeUint32(&msg, preludeCRC)
+	msg.Write(headerBuf.Bytes())
+	msg.Write(payload)
+
+	msgCRC := crc32.ChecksumIEEE(msg.Bytes())
+	writeUint32(&msg, msgCRC)
+
+	_, err := f.w.Write(msg.Bytes())
+	if flusher, ok := f.w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+	return err
+}
+
+// orderedHeaderNames keeps the well-known event-stream headers in a stable
+// order so framing is deterministic and easy to test.
+func orderedHeaderNames(headers map[string]string) []string {
+	preferred := []string{":message-type", ":event-type", ":content-type"}
+	names := make([]string, 0, len(headers))
+	for _, p := range preferred {
+		if _, ok := headers[p]; ok {
+			names = append(names, p)
+		}
+	}
+	return names
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+func writeUint16(buf *bytes.Buffer, v uint16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	buf.Write(b[:])
+}
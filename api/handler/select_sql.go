@@ -0,0 +1,573 @@
+package handler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// record is a single input row made available to SQL evaluation. CSV rows are
+// addressable both positionally (`_1`, `_2`, ...) and, when a header is
+// present, by column name; JSON rows are addressable by field name.
+type record struct {
+	values []string
+	header []string
+	doc    map[string]interface{}
+}
+
+func (r *record) get(name string) (interface{}, bool) {
+	if strings.HasPrefix(name, "_") {
+		idx, err := strconv.Atoi(strings.TrimPrefix(name, "_"))
+		if err == nil && idx >= 1 && idx <= len(r.values) {
+			return r.values[idx-1], true
+		}
+	}
+	for i, h := range r.header {
+		if h == name && i < len(r.values) {
+			return r.values[i], true
+		}
+	}
+	if r.doc != nil {
+		v, ok := r.doc[name]
+		return v, ok
+	}
+	return nil, false
+}
+
+// selectQuery is a parsed `SELECT ... FROM S3Object ...` statement.
+type selectQuery struct {
+	Star     bool
+	CountAll bool
+	Columns  []selectColumn
+	Alias    string
+	Where    sqlExpr
+	Limit    int
+}
+
+type selectColumn struct {
+	Expr  sqlExpr
+	Alias string
+}
+
+// Eval reports whether the record satisfies the query's WHERE clause.
+func (q *selectQuery) Eval(r *record) (bool, error) {
+	if q.Where == nil {
+		return true, nil
+	}
+	v, err := q.Where.eval(r)
+	if err != nil {
+		return false, err
+	}
+	b, _ := v.(bool)
+	return b, nil
+}
+
+// sqlExpr is a node of the parsed SQL expression tree.
+type sqlExpr interface {
+	eval(r *record) (interface{}, error)
+}
+
+type litExpr struct{ v interface{} }
+
+func (e litExpr) eval(*record) (interface{}, error) { return e.v, nil }
+
+type colExpr struct{ name string }
+
+func (e colExpr) eval(r *record) (interface{}, error) {
+	v, _ := r.get(e.name)
+	return v, nil
+}
+
+type unaryExpr struct {
+	op string
+	x  sqlExpr
+}
+
+func (e unaryExpr) eval(r *record) (interface{}, error) {
+	v, err := e.x.eval(r)
+	if err != nil {
+		return nil, err
+	}
+	switch e.op {
+	case "NOT":
+		return !truthy(v), nil
+	case "-":
+		return -toFloat(v), nil
+	case "IS NULL":
+		return v == nil, nil
+	case "IS NOT NULL":
+		return v != nil, nil
+	}
+	return nil, fmt.Errorf("unsupported unary operator %q", e.op)
+}
+
+type binaryExpr struct {
+	op   string
+	l, r sqlExpr
+}
+
+func (e binaryExpr) eval(r *record) (interface{}, error) {
+	lv, err := e.l.eval(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "AND":
+		if !truthy(lv) {
+			return false, nil
+		}
+		rv, err := e.r.eval(r)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(rv), nil
+	case "OR":
+		if truthy(lv) {
+			return true, nil
+		}
+		rv, err := e.r.eval(r)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(rv), nil
+	}
+
+	rv, err := e.r.eval(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "=":
+		return equalValues(lv, rv), nil
+	case "!=", "<>":
+		return !equalValues(lv, rv), nil
+	case "<":
+		return toFloat(lv) < toFloat(rv), nil
+	case "<=":
+		return toFloat(lv) <= toFloat(rv), nil
+	case ">":
+		return toFloat(lv) > toFloat(rv), nil
+	case ">=":
+		return toFloat(lv) >= toFloat(rv), nil
+	case "+":
+		return toFloat(lv) + toFloat(rv), nil
+	case "-":
+		return toFloat(lv) - toFloat(rv), nil
+	case "*":
+		return toFloat(lv) * toFloat(rv), nil
+	case "/":
+		return toFloat(lv) / toFloat(rv), nil
+	case "LIKE":
+		return likeMatch(fmt.Sprint(lv), fmt.Sprint(rv)), nil
+	}
+	return nil, fmt.Errorf("unsupported binary operator %q", e.op)
+}
+
+type funcExpr struct {
+	name string
+	args []sqlExpr
+}
+
+func (e funcExpr) eval(r *record) (interface{}, error) {
+	switch strings.ToUpper(e.name) {
+	case "COUNT":
+		return int64(1), nil
+	case "LOWER":
+		v, err := e.arg(r, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToLower(fmt.Sprint(v)), nil
+	case "UPPER":
+		v, err := e.arg(r, 0)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToUpper(fmt.Sprint(v)), nil
+	case "SUBSTRING":
+		s, err := e.arg(r, 0)
+		if err != nil {
+			return nil, err
+		}
+		start := int(toFloat(mustEval(e.args[1], r)))
+		str := fmt.Sprint(s)
+		if start < 1 {
+			start = 1
+		}
+		if start > len(str) {
+			return "", nil
+		}
+		end := len(str)
+		if len(e.args) > 2 {
+			length := int(toFloat(mustEval(e.args[2], r)))
+			if start-1+length < end {
+				end = start - 1 + length
+			}
+		}
+		return str[start-1 : end], nil
+	case "CAST":
+		return e.arg(r, 0)
+	default:
+		return nil, fmt.Errorf("unsupported function %q", e.name)
+	}
+}
+
+func (e funcExpr) arg(r *record, i int) (interface{}, error) {
+	if i >= len(e.args) {
+		return nil, nil
+	}
+	return e.args[i].eval(r)
+}
+
+func mustEval(e sqlExpr, r *record) interface{} {
+	v, _ := e.eval(r)
+	return v
+}
+
+func truthy(v interface{}) bool {
+	b, ok := v.(bool)
+	return ok && b
+}
+
+func toFloat(v interface{}) float64 {
+	switch t := v.(type) {
+	case float64:
+		return t
+	case int64:
+		return float64(t)
+	case string:
+		f, _ := strconv.ParseFloat(t, 64)
+		return f
+	case bool:
+		if t {
+			return 1
+		}
+		return 0
+	default:
+		return 0
+	}
+}
+
+func equalValues(a, b interface{}) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// likeMatch reports whether s matches the SQL LIKE pattern, where `%` stands
+// for any run of characters and `_` for exactly one.
+func likeMatch(s, pattern string) bool {
+	var re strings.Builder
+	re.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			re.WriteString(".*")
+		case '_':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+
+	matched, err := regexp.MatchString(re.String(), s)
+	return err == nil && matched
+}
+
+// parseSelectQuery parses a minimal subset of the S3 Select SQL dialect:
+//
+//	SELECT <projection> FROM S3Object [alias] [WHERE <predicate>] [LIMIT n]
+func parseSelectQuery(expr string) (*selectQuery, error) {
+	toks, err := tokenizeSQL(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &sqlParser{toks: toks}
+	return p.parseSelect()
+}
+
+type sqlParser struct {
+	toks []string
+	pos  int
+}
+
+func (p *sqlParser) peek() string {
+	if p.pos >= len(p.toks) {
+		return ""
+	}
+	return p.toks[p.pos]
+}
+
+func (p *sqlParser) peekUpper() string {
+	return strings.ToUpper(p.peek())
+}
+
+func (p *sqlParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *sqlParser) expect(kw string) error {
+	if p.peekUpper() != kw {
+		return fmt.Errorf("expected %q, got %q", kw, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+func (p *sqlParser) parseSelect() (*selectQuery, error) {
+	if err := p.expect("SELECT"); err != nil {
+		return nil, err
+	}
+
+	q := &selectQuery{}
+	if p.peek() == "*" {
+		p.next()
+		q.Star = true
+	} else if p.peekUpper() == "COUNT" && p.pos+2 < len(p.toks) && p.toks[p.pos+1] == "(" && p.toks[p.pos+2] == "*" {
+		p.pos += 4 // COUNT ( * )
+		q.CountAll = true
+	} else {
+		for {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			col := selectColumn{Expr: e}
+			if p.peekUpper() == "AS" {
+				p.next()
+				col.Alias = p.next()
+			}
+			q.Columns = append(q.Columns, col)
+			if p.peek() != "," {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if err := p.expect("FROM"); err != nil {
+		return nil, err
+	}
+	if err := p.expect("S3OBJECT"); err != nil {
+		return nil, err
+	}
+	if p.peek() != "" && p.peekUpper() != "WHERE" && p.peekUpper() != "LIMIT" {
+		q.Alias = p.next()
+	}
+
+	if p.peekUpper() == "WHERE" {
+		p.next()
+		where, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		q.Where = where
+	}
+
+	if p.peekUpper() == "LIMIT" {
+		p.next()
+		n, err := strconv.Atoi(p.next())
+		if err != nil {
+			return nil, fmt.Errorf("invalid LIMIT: %w", err)
+		}
+		q.Limit = n
+	}
+
+	return q, nil
+}
+
+// operator precedence, lowest to highest.
+var precedence = [][]string{
+	{"OR"},
+	{"AND"},
+	{"=", "!=", "<>", "<", "<=", ">", ">=", "LIKE"},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+func (p *sqlParser) parseExpr() (sqlExpr, error) {
+	return p.parseLevel(0)
+}
+
+func (p *sqlParser) parseLevel(level int) (sqlExpr, error) {
+	if level >= len(precedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseLevel(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for containsUpper(precedence[level], p.peekUpper()) {
+		op := strings.ToUpper(p.next())
+		right, err := p.parseLevel(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, l: left, r: right}
+	}
+
+	if level == len(precedence)-1 {
+		// allow trailing IS NULL / IS NOT NULL which binds tighter than comparisons.
+		for p.peekUpper() == "IS" {
+			p.next()
+			op := "IS NULL"
+			if p.peekUpper() == "NOT" {
+				p.next()
+				op = "IS NOT NULL"
+			}
+			if err := p.expect("NULL"); err != nil {
+				return nil, err
+			}
+			left = unaryExpr{op: op, x: left}
+		}
+	}
+
+	return left, nil
+}
+
+func (p *sqlParser) parseUnary() (sqlExpr, error) {
+	switch p.peekUpper() {
+	case "NOT":
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryExpr{op: "NOT", x: x}, nil
+	case "-":
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryExpr{op: "-", x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *sqlParser) parsePrimary() (sqlExpr, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case tok == "(":
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("expected closing parenthesis")
+		}
+		return e, nil
+	case tok == "TRUE":
+		return litExpr{v: true}, nil
+	case tok == "FALSE":
+		return litExpr{v: false}, nil
+	case strings.HasPrefix(tok, "'"):
+		return litExpr{v: strings.Trim(tok, "'")}, nil
+	case isNumberToken(tok):
+		f, _ := strconv.ParseFloat(tok, 64)
+		return litExpr{v: f}, nil
+	case p.peek() == "(":
+		p.next()
+		var args []sqlExpr
+		for p.peek() != ")" {
+			if p.peek() == "*" {
+				p.next()
+				continue
+			}
+			a, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, a)
+			if p.peek() == "," {
+				p.next()
+			}
+		}
+		p.next() // ")"
+		return funcExpr{name: tok, args: args}, nil
+	default:
+		return colExpr{name: stripAlias(tok)}, nil
+	}
+}
+
+// stripAlias drops a leading `<alias>.` from a qualified column reference
+// (e.g. `s.age` -> `age`). The grammar only ever qualifies a column with the
+// FROM-clause alias, so the alias itself doesn't need to be tracked - any
+// text before the first dot can be discarded.
+func stripAlias(name string) string {
+	if idx := strings.IndexByte(name, '.'); idx >= 0 {
+		return name[idx+1:]
+	}
+	return name
+}
+
+func containsUpper(set []string, v string) bool {
+	for _, s := range set {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumberToken(tok string) bool {
+	if tok == "" {
+		return false
+	}
+	_, err := strconv.ParseFloat(tok, 64)
+	return err == nil
+}
+
+// tokenizeSQL splits a SQL expression into a flat token stream, keeping
+// quoted strings intact and multi-char operators (!=, <=, >=, <>) whole.
+func tokenizeSQL(expr string) ([]string, error) {
+	var toks []string
+	r := []rune(expr)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '\'':
+			j := i + 1
+			for j < len(r) && r[j] != '\'' {
+				j++
+			}
+			if j >= len(r) {
+				return nil, fmt.Errorf("unterminated string literal")
+			}
+			toks = append(toks, string(r[i:j+1]))
+			i = j + 1
+		case strings.ContainsRune("(),*+-/", c):
+			toks = append(toks, string(c))
+			i++
+		case strings.ContainsRune("=<>!", c):
+			if i+1 < len(r) && r[i+1] == '=' {
+				toks = append(toks, string(r[i:i+2]))
+				i += 2
+			} else if c == '<' && i+1 < len(r) && r[i+1] == '>' {
+				toks = append(toks, "<>")
+				i += 2
+			} else {
+				toks = append(toks, string(c))
+				i++
+			}
+		default:
+			j := i
+			for j < len(r) && !strings.ContainsRune(" \t\n(),*+-/=<>!'", r[j]) {
+				j++
+			}
+			toks = append(toks, string(r[i:j]))
+			i = j
+		}
+	}
+	return toks, nil
+}
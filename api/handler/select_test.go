@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelectQueryWhereAndLimit(t *testing.T) {
+	q, err := parseSelectQuery(`SELECT s.name, s.age FROM S3Object s WHERE s.age > 18 LIMIT 2`)
+	require.NoError(t, err)
+	require.Len(t, q.Columns, 2)
+	require.Equal(t, 2, q.Limit)
+
+	ok, err := q.Eval(&record{doc: map[string]interface{}{"age": float64(21)}})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = q.Eval(&record{doc: map[string]interface{}{"age": float64(10)}})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestParseSelectQueryStarAndCount(t *testing.T) {
+	q, err := parseSelectQuery(`SELECT * FROM S3Object`)
+	require.NoError(t, err)
+	require.True(t, q.Star)
+
+	q, err = parseSelectQuery(`SELECT COUNT(*) FROM S3Object`)
+	require.NoError(t, err)
+	require.True(t, q.CountAll)
+}
+
+func TestCSVRecordReaderWithHeader(t *testing.T) {
+	src := bytes.NewBufferString("name,age\nAlice,30\nBob,25\n")
+	rr := newCSVRecordReader(src, &csvInput{FileHeaderInfo: "USE"})
+
+	rec, _, err := rr.Next()
+	require.NoError(t, err)
+	v, ok := rec.get("name")
+	require.True(t, ok)
+	require.Equal(t, "Alice", v)
+
+	rec, _, err = rr.Next()
+	require.NoError(t, err)
+	v, ok = rec.get("_2")
+	require.True(t, ok)
+	require.Equal(t, "25", v)
+}
+
+func TestJSONRecordReaderLike(t *testing.T) {
+	src := bytes.NewBufferString(`{"city":"Berlin"}{"city":"Paris"}`)
+	rr := newJSONRecordReader(src, &jsonInput{Type: "LINES"})
+
+	q, err := parseSelectQuery(`SELECT s.city FROM S3Object s WHERE s.city LIKE 'Ber%'`)
+	require.NoError(t, err)
+
+	var matched []string
+	for {
+		rec, _, err := rr.Next()
+		if err != nil {
+			break
+		}
+		ok, err := q.Eval(rec)
+		require.NoError(t, err)
+		if ok {
+			v, _ := rec.get("city")
+			matched = append(matched, v.(string))
+		}
+	}
+	require.Equal(t, []string{"Berlin"}, matched)
+}
+
+func TestDecompressGZIP(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte("a,b\n1,2\n"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	r, err := decompress(&buf, compressionGZIP)
+	require.NoError(t, err)
+
+	rr := newCSVRecordReader(r, &csvInput{})
+	rec, _, err := rr.Next()
+	require.NoError(t, err)
+	v, _ := rec.get("_1")
+	require.Equal(t, "a", v)
+}
+
+func TestRecordEncoderStarProjectionIsDeterministicallyOrdered(t *testing.T) {
+	q, err := parseSelectQuery(`SELECT * FROM S3Object`)
+	require.NoError(t, err)
+	enc := newRecordEncoder(outputSerialization{CSV: &csvOutput{}})
+	rec := &record{doc: map[string]interface{}{"city": "Berlin", "age": float64(30), "zip": "10115"}}
+
+	first, err := enc.Encode(rec, q)
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		got, err := enc.Encode(rec, q)
+		require.NoError(t, err)
+		require.Equal(t, first, got, "column order must not vary between calls")
+	}
+}
+
+func TestEventStreamFramerMultiMessage(t *testing.T) {
+	var out bytes.Buffer
+	framer := newEventStreamFramer(&out)
+
+	require.NoError(t, framer.WriteRecords([]byte("1,2\n")))
+	require.NoError(t, framer.WriteRecords([]byte("3,4\n")))
+	require.NoError(t, framer.WriteStats(8, 8))
+	require.NoError(t, framer.WriteEnd())
+
+	data := out.Bytes()
+	var messages int
+	for len(data) > 0 {
+		require.GreaterOrEqual(t, len(data), 12)
+		totalLen := binary.BigEndian.Uint32(data[0:4])
+		require.LessOrEqual(t, int(totalLen), len(data))
+		data = data[totalLen:]
+		messages++
+	}
+	require.Equal(t, 4, messages)
+}
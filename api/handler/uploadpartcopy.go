@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/nspcc-dev/neofs-s3-gw/api"
+	"github.com/nspcc-dev/neofs-s3-gw/api/errors"
+	"github.com/nspcc-dev/neofs-s3-gw/api/layer"
+)
+
+// CopyPartResult is the response body of UploadPartCopy.
+type CopyPartResult struct {
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+}
+
+// UploadPartCopyHandler implements the S3
+// `PUT /{bucket}/{object}?partNumber=N&uploadId=ID` operation with a copy
+// source, i.e. uploading a multipart part by copying (a range of) an
+// existing object instead of streaming new bytes.
+func (h *handler) UploadPartCopyHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		reqInfo = api.GetReqInfo(r.Context())
+		vars    = mux.Vars(r)
+	)
+
+	uploadID := r.URL.Query().Get(api.UploadID)
+	partNumber, err := strconv.Atoi(r.URL.Query().Get(api.PartNumber))
+	if err != nil || partNumber < 1 || partNumber > layer.MaxPartNumber {
+		h.logAndSendError(w, "invalid part number", reqInfo, errors.GetAPIError(errors.ErrInvalidPartNumber))
+		return
+	}
+
+	src := r.Header.Get(api.AmzCopySource)
+	var versionID string
+	if u, err := url.Parse(src); err == nil {
+		versionID = u.Query().Get(api.QueryVersionID)
+		src = u.Path
+	}
+
+	srcBucket, srcObject, err := path2BucketObject(src)
+	if err != nil {
+		h.logAndSendError(w, "invalid source copy", reqInfo, err)
+		return
+	}
+
+	srcBktInfo, err := h.getBucketAndCheckOwner(r, srcBucket, api.AmzSourceExpectedBucketOwner)
+	if err != nil {
+		h.logAndSendError(w, "couldn't get source bucket", reqInfo, err)
+		return
+	}
+
+	dstBktInfo, err := h.getBucketAndCheckOwner(r, reqInfo.BucketName)
+	if err != nil {
+		h.logAndSendError(w, "couldn't get target bucket", reqInfo, err)
+		return
+	}
+
+	objInfo, err := h.obj.GetObjectInfo(r.Context(), &layer.HeadObjectParams{
+		BktInfo:   srcBktInfo,
+		Object:    srcObject,
+		VersionID: versionID,
+	})
+	if err != nil {
+		h.logAndSendError(w, "could not find source object", reqInfo, err)
+		return
+	}
+
+	rng, err := parseCopySourceRange(r.Header.Get(api.AmzCopySourceRange), objInfo.Size)
+	if err != nil {
+		h.logAndSendError(w, "invalid copy source range", reqInfo, errors.GetAPIError(errors.ErrInvalidRange))
+		return
+	}
+
+	encryptionParams, err := h.formEncryptionParams(r.Header)
+	if err != nil {
+		h.logAndSendError(w, "invalid sse headers", reqInfo, err)
+		return
+	}
+
+	p := &layer.UploadCopyPartParams{
+		Info: &layer.UploadInfoParams{
+			UploadID: uploadID,
+			Bkt:      dstBktInfo,
+			Key:      vars["object"],
+		},
+		PartNumber: partNumber,
+		SrcObject:  objInfo,
+		SrcBktInfo: srcBktInfo,
+		Range:      rng,
+		Encryption: encryptionParams,
+	}
+
+	info, err := h.obj.UploadPartCopy(r.Context(), p)
+	if err != nil {
+		h.logAndSendError(w, "could not upload part copy", reqInfo, err)
+		return
+	}
+
+	if err = api.EncodeToResponse(w, &CopyPartResult{
+		ETag:         info.HashSum,
+		LastModified: info.Created.UTC().Format(time.RFC3339),
+	}); err != nil {
+		h.logAndSendError(w, "something went wrong", reqInfo, err)
+		return
+	}
+}
+
+// parseCopySourceRange parses the `x-amz-copy-source-range: bytes=start-end`
+// header and validates it against the source object's size. An empty header
+// means "copy the whole object" and returns a nil range.
+func parseCopySourceRange(header string, srcSize uint64) (*layer.RangeParams, error) {
+	if header == "" {
+		return nil, nil
+	}
+
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return nil, fmt.Errorf("invalid range header: %q", header)
+	}
+
+	bounds := strings.SplitN(strings.TrimPrefix(header, prefix), "-", 2)
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid range header: %q", header)
+	}
+
+	start, err := strconv.ParseUint(bounds[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range start: %w", err)
+	}
+	end, err := strconv.ParseUint(bounds[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range end: %w", err)
+	}
+
+	if start > end || end >= srcSize {
+		return nil, fmt.Errorf("range %d-%d out of bounds for object of size %d", start, end, srcSize)
+	}
+
+	return &layer.RangeParams{Start: start, End: end}, nil
+}
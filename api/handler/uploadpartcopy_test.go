@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCopySourceRange(t *testing.T) {
+	rng, err := parseCopySourceRange("", 100)
+	require.NoError(t, err)
+	require.Nil(t, rng)
+
+	rng, err = parseCopySourceRange("bytes=0-9", 100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), rng.Start)
+	require.Equal(t, uint64(9), rng.End)
+
+	_, err = parseCopySourceRange("bytes=10-5", 100)
+	require.Error(t, err)
+
+	_, err = parseCopySourceRange("bytes=0-99", 50)
+	require.Error(t, err)
+
+	_, err = parseCopySourceRange("not-a-range", 100)
+	require.Error(t, err)
+}
@@ -2,21 +2,45 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/nspcc-dev/neofs-s3-gw/api/accesskey"
 	"github.com/nspcc-dev/neofs-s3-gw/api/auth"
+	"github.com/nspcc-dev/neofs-s3-gw/metrics"
 	"go.uber.org/zap"
 )
 
+// errAccessKeyRejected is returned by checkAccessKeyUsable when the request's
+// access key is known but currently revoked, disabled, or past its rotation
+// grace period.
+var errAccessKeyRejected = errors.New("access key is not usable")
+
 // KeyWrapper is wrapper for context keys.
 type KeyWrapper string
 
 // BoxData is an ID used to store accessbox.Box in a context.
 var BoxData = KeyWrapper("__context_box_key")
 
+// credentialAKIDMatcher extracts the access key ID out of the SigV4
+// `Authorization: AWS4-HMAC-SHA256 Credential=<AKID>/...` header.
+var credentialAKIDMatcher = regexp.MustCompile(`Credential=([^/,\s]+)`)
+
 // AttachUserAuth adds user authentication via center to router using log for logging.
 func AttachUserAuth(router *mux.Router, center auth.Center, log *zap.Logger) {
+	AttachUserAuthWithAccessKeys(router, center, nil, nil, nil, log)
+}
+
+// AttachUserAuthWithAccessKeys adds user authentication via center to router,
+// additionally consulting keys (if non-nil) so that a revoked or disabled
+// access key is rejected with ErrAccessDenied before BoxData is populated.
+// keyMetrics, if non-nil, records access-key attempt/rejection counters;
+// apiMetrics, if non-nil, records the overall auth outcome (s3_auth_total).
+func AttachUserAuthWithAccessKeys(router *mux.Router, center auth.Center, keys accesskey.Service, keyMetrics *accesskey.Metrics, apiMetrics *metrics.APIMetrics, log *zap.Logger) {
 	router.Use(func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			var ctx context.Context
@@ -27,10 +51,20 @@ func AttachUserAuth(router *mux.Router, center auth.Center, log *zap.Logger) {
 					ctx = r.Context()
 				} else {
 					log.Error("failed to pass authentication", zap.Error(err))
+					observeAuthOutcome(apiMetrics, "denied")
 					WriteErrorResponse(r.Context(), w, GetAPIError(ErrAccessDenied), r.URL)
 					return
 				}
 			} else {
+				if keys != nil {
+					if err = checkAccessKeyUsable(r, keys, keyMetrics); err != nil {
+						log.Error("access key rejected", zap.Error(err))
+						observeAuthOutcome(apiMetrics, "denied")
+						WriteErrorResponse(r.Context(), w, GetAPIError(ErrAccessDenied), r.URL)
+						return
+					}
+				}
+				observeAuthOutcome(apiMetrics, "success")
 				ctx = context.WithValue(r.Context(), BoxData, box)
 			}
 
@@ -38,3 +72,65 @@ func AttachUserAuth(router *mux.Router, center auth.Center, log *zap.Logger) {
 		})
 	})
 }
+
+// observeAuthOutcome records result on m, if m is set.
+func observeAuthOutcome(m *metrics.APIMetrics, result string) {
+	if m != nil {
+		m.ObserveAuth(result)
+	}
+}
+
+// checkAccessKeyUsable rejects requests signed with a revoked, disabled or
+// grace-period-expired access key before BoxData is populated for handlers.
+func checkAccessKeyUsable(r *http.Request, keys accesskey.Service, keyMetrics *accesskey.Metrics) error {
+	akid := extractAKID(r)
+	if akid == "" {
+		return nil
+	}
+
+	if keyMetrics != nil {
+		keyMetrics.ObserveAttempt()
+	}
+
+	key, err := keys.Get(r.Context(), akid)
+	if err != nil {
+		if err == accesskey.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if !key.Usable(time.Now()) {
+		if keyMetrics != nil {
+			keyMetrics.ObserveRejection(rejectionReason(key))
+		}
+		return errAccessKeyRejected
+	}
+	return nil
+}
+
+func rejectionReason(key *accesskey.AccessKey) string {
+	switch {
+	case key.Status == accesskey.StatusRevoked:
+		return "revoked"
+	case key.Status == accesskey.StatusDisabled:
+		return "disabled"
+	default:
+		return "expired"
+	}
+}
+
+// extractAKID pulls the access key ID out of the SigV4 Authorization header
+// or, for presigned URLs, the X-Amz-Credential query parameter.
+func extractAKID(r *http.Request) string {
+	if m := credentialAKIDMatcher.FindStringSubmatch(r.Header.Get("Authorization")); len(m) == 2 {
+		return m[1]
+	}
+	if cred := r.URL.Query().Get("X-Amz-Credential"); cred != "" {
+		if idx := strings.Index(cred, "/"); idx >= 0 {
+			return cred[:idx]
+		}
+		return cred
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ContainerStore is the minimal FrostFS surface the lifecycle subsystem
+// needs to discover buckets and act on their objects and multipart uploads.
+// It mirrors the primitives the gateway's own object layer already exposes
+// to the S3 handlers, narrowed down to what Scanner depends on.
+type ContainerStore interface {
+	ListBuckets(ctx context.Context) ([]string, error)
+	GetLifecycleConfiguration(ctx context.Context, bucket string) (*Configuration, error)
+	// ListObjects must set ObjectSummary.HasNoncurrentVersions on every
+	// delete-marker entry by grouping the bucket's objects by key, so
+	// Rule.Evaluate can honor Expiration.ExpiredObjectDeleteMarker.
+	ListObjects(ctx context.Context, bucket string) ([]ObjectSummary, error)
+	ListIncompleteMultipartUploads(ctx context.Context, bucket string) ([]MultipartUploadSummary, error)
+	DeleteObject(ctx context.Context, bucket string, obj ObjectSummary) error
+	AbortMultipartUpload(ctx context.Context, bucket string, upload MultipartUploadSummary) error
+}
+
+// frostFSStore adapts a ContainerStore to the BucketSource, ObjectSource and
+// ObjectSink interfaces Scanner depends on.
+type frostFSStore struct {
+	store ContainerStore
+}
+
+// NewFrostFSStore builds the BucketSource, ObjectSource and ObjectSink
+// Scanner needs, all backed by store.
+func NewFrostFSStore(store ContainerStore) (BucketSource, ObjectSource, ObjectSink) {
+	s := &frostFSStore{store: store}
+	return s, s, s
+}
+
+func (s *frostFSStore) ListBuckets(ctx context.Context) ([]string, error) {
+	return s.store.ListBuckets(ctx)
+}
+
+func (s *frostFSStore) GetConfiguration(ctx context.Context, bucket string) (*Configuration, error) {
+	return s.store.GetLifecycleConfiguration(ctx, bucket)
+}
+
+func (s *frostFSStore) ListObjects(ctx context.Context, bucket string) ([]ObjectSummary, error) {
+	return s.store.ListObjects(ctx, bucket)
+}
+
+func (s *frostFSStore) ListIncompleteMultipartUploads(ctx context.Context, bucket string) ([]MultipartUploadSummary, error) {
+	return s.store.ListIncompleteMultipartUploads(ctx, bucket)
+}
+
+func (s *frostFSStore) ExpireObject(ctx context.Context, bucket string, obj ObjectSummary) error {
+	return s.store.DeleteObject(ctx, bucket, obj)
+}
+
+func (s *frostFSStore) AbortMultipartUpload(ctx context.Context, bucket string, upload MultipartUploadSummary) error {
+	return s.store.AbortMultipartUpload(ctx, bucket, upload)
+}
+
+// LockObjectStore is the FrostFS primitive a frostFSLeaseStore needs: a
+// single lock object per key, recording its current owner and expiry.
+// FrostFS objects are immutable, so "renewing" a lease means deleting the
+// old lock object and writing a new one rather than an in-place update.
+type LockObjectStore interface {
+	GetLock(ctx context.Context, key string) (owner string, expiresAt time.Time, found bool, err error)
+	PutLock(ctx context.Context, key, owner string, expiresAt time.Time) error
+	DeleteLock(ctx context.Context, key, owner string) error
+}
+
+// frostFSLeaseStore implements LeaseStore as a single lock object per key in
+// a system container: every replica reads the
+// current (owner, expiry) before writing, rather than relying on a separate
+// distributed lock manager.
+type frostFSLeaseStore struct {
+	store LockObjectStore
+}
+
+// NewFrostFSLeaseStore builds a LeaseStore backed by store.
+func NewFrostFSLeaseStore(store LockObjectStore) LeaseStore {
+	return &frostFSLeaseStore{store: store}
+}
+
+func (l *frostFSLeaseStore) TryAcquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	existingOwner, expiresAt, found, err := l.store.GetLock(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("could not read lease: %w", err)
+	}
+	if found && existingOwner != owner && time.Now().Before(expiresAt) {
+		return false, nil
+	}
+
+	if err = l.store.PutLock(ctx, key, owner, time.Now().Add(ttl)); err != nil {
+		return false, fmt.Errorf("could not write lease: %w", err)
+	}
+	return true, nil
+}
+
+func (l *frostFSLeaseStore) Release(ctx context.Context, key, owner string) error {
+	existingOwner, _, found, err := l.store.GetLock(ctx, key)
+	if err != nil {
+		return fmt.Errorf("could not read lease: %w", err)
+	}
+	if !found || existingOwner != owner {
+		return nil
+	}
+	return l.store.DeleteLock(ctx, key, owner)
+}
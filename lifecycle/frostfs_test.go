@@ -0,0 +1,86 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type lockRecord struct {
+	owner     string
+	expiresAt time.Time
+}
+
+type memLockObjectStore struct {
+	locks map[string]lockRecord
+}
+
+func newMemLockObjectStore() *memLockObjectStore {
+	return &memLockObjectStore{locks: make(map[string]lockRecord)}
+}
+
+func (m *memLockObjectStore) GetLock(_ context.Context, key string) (string, time.Time, bool, error) {
+	rec, ok := m.locks[key]
+	if !ok {
+		return "", time.Time{}, false, nil
+	}
+	return rec.owner, rec.expiresAt, true, nil
+}
+
+func (m *memLockObjectStore) PutLock(_ context.Context, key, owner string, expiresAt time.Time) error {
+	m.locks[key] = lockRecord{owner: owner, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *memLockObjectStore) DeleteLock(_ context.Context, key, owner string) error {
+	if m.locks[key].owner == owner {
+		delete(m.locks, key)
+	}
+	return nil
+}
+
+func TestFrostFSLeaseStoreAcquireAndRelease(t *testing.T) {
+	store := newMemLockObjectStore()
+	leases := NewFrostFSLeaseStore(store)
+
+	acquired, err := leases.TryAcquire(context.Background(), "bucket1", "replica-1", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// A second replica can't acquire a live lease.
+	acquired, err = leases.TryAcquire(context.Background(), "bucket1", "replica-2", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	require.NoError(t, leases.Release(context.Background(), "bucket1", "replica-1"))
+
+	acquired, err = leases.TryAcquire(context.Background(), "bucket1", "replica-2", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
+
+func TestFrostFSLeaseStoreExpiredLeaseCanBeTakenOver(t *testing.T) {
+	store := newMemLockObjectStore()
+	require.NoError(t, store.PutLock(context.Background(), "bucket1", "replica-1", time.Now().Add(-time.Minute)))
+
+	leases := NewFrostFSLeaseStore(store)
+
+	acquired, err := leases.TryAcquire(context.Background(), "bucket1", "replica-2", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
+
+func TestFrostFSLeaseStoreReleaseIgnoresOtherOwners(t *testing.T) {
+	store := newMemLockObjectStore()
+	require.NoError(t, store.PutLock(context.Background(), "bucket1", "replica-1", time.Now().Add(time.Minute)))
+
+	leases := NewFrostFSLeaseStore(store)
+	require.NoError(t, leases.Release(context.Background(), "bucket1", "replica-2"))
+
+	owner, _, found, err := store.GetLock(context.Background(), "bucket1")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "replica-1", owner)
+}
@@ -0,0 +1,22 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+)
+
+// LeaseStore coordinates which gateway replica owns the right to scan a
+// given bucket, backed by a single lock object per bucket in its container.
+type LeaseStore interface {
+	// TryAcquire attempts to take (or renew, if already held by owner) the
+	// lease on key for ttl. It returns false, without error, if another
+	// owner currently holds a live lease.
+	TryAcquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Release drops the lease on key if held by owner.
+	Release(ctx context.Context, key, owner string) error
+}
+
+// leaseKey derives the lock object key for a bucket's lifecycle scan.
+func leaseKey(bucket string) string {
+	return "lifecycle-scan-lease/" + bucket
+}
@@ -0,0 +1,57 @@
+package lifecycle
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	namespace = "frostfs_s3"
+	subsystem = "lifecycle"
+)
+
+// Metrics counts actions the scanner takes (or would take, in dry-run mode)
+// per rule and action kind.
+type Metrics struct {
+	actionsTotal *prometheus.CounterVec
+	scansTotal   *prometheus.CounterVec
+}
+
+// NewMetrics builds and registers the lifecycle scanner counters.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		actionsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "actions_total",
+			Help:      "Total number of lifecycle actions taken (or, in dry-run mode, that would have been taken), by bucket, rule and action.",
+		}, []string{"bucket", "rule", "action", "dry_run"}),
+
+		scansTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "scans_total",
+			Help:      "Total number of completed bucket lifecycle scans, by outcome.",
+		}, []string{"bucket", "outcome"}),
+	}
+	prometheus.MustRegister(m.actionsTotal, m.scansTotal)
+	return m
+}
+
+// Unregister removes the counters from the default registry.
+func (m *Metrics) Unregister() {
+	prometheus.Unregister(m.actionsTotal)
+	prometheus.Unregister(m.scansTotal)
+}
+
+func (m *Metrics) observeAction(bucket, rule string, action Action, dryRun bool) {
+	m.actionsTotal.WithLabelValues(bucket, rule, string(action), dryRunLabel(dryRun)).Inc()
+}
+
+func (m *Metrics) observeScan(bucket, outcome string) {
+	m.scansTotal.WithLabelValues(bucket, outcome).Inc()
+}
+
+func dryRunLabel(dryRun bool) string {
+	if dryRun {
+		return "true"
+	}
+	return "false"
+}
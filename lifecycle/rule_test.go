@@ -0,0 +1,102 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleEvaluateExpiresCurrentVersionByDays(t *testing.T) {
+	rule := Rule{
+		Status:     StatusEnabled,
+		Expiration: &Expiration{Days: 30},
+	}
+	now := time.Now()
+
+	obj := ObjectSummary{IsCurrent: true, Created: now.Add(-31 * 24 * time.Hour)}
+	require.Equal(t, ActionExpireCurrentVersion, rule.Evaluate(obj, now))
+
+	freshObj := ObjectSummary{IsCurrent: true, Created: now.Add(-1 * time.Hour)}
+	require.Equal(t, ActionNone, rule.Evaluate(freshObj, now))
+}
+
+func TestRuleEvaluateDisabledNeverMatches(t *testing.T) {
+	rule := Rule{
+		Status:     StatusDisabled,
+		Expiration: &Expiration{Days: 1},
+	}
+	obj := ObjectSummary{IsCurrent: true, Created: time.Now().Add(-365 * 24 * time.Hour)}
+	require.Equal(t, ActionNone, rule.Evaluate(obj, time.Now()))
+}
+
+func TestRuleEvaluateNoncurrentVersionExpiration(t *testing.T) {
+	rule := Rule{
+		Status:                      StatusEnabled,
+		NoncurrentVersionExpiration: &NoncurrentVersionExpiration{NoncurrentDays: 7},
+	}
+	now := time.Now()
+
+	old := ObjectSummary{IsCurrent: false, Created: now.Add(-8 * 24 * time.Hour)}
+	require.Equal(t, ActionExpireNoncurrent, rule.Evaluate(old, now))
+
+	recent := ObjectSummary{IsCurrent: false, Created: now.Add(-1 * 24 * time.Hour)}
+	require.Equal(t, ActionNone, rule.Evaluate(recent, now))
+}
+
+func TestRuleEvaluateExpiredObjectDeleteMarker(t *testing.T) {
+	rule := Rule{
+		Status:     StatusEnabled,
+		Expiration: &Expiration{ExpiredObjectDeleteMarker: true},
+	}
+	now := time.Now()
+
+	bare := ObjectSummary{IsCurrent: true, IsDeleteMark: true, HasNoncurrentVersions: false}
+	require.Equal(t, ActionExpireCurrentVersion, rule.Evaluate(bare, now))
+
+	covered := ObjectSummary{IsCurrent: true, IsDeleteMark: true, HasNoncurrentVersions: true}
+	require.Equal(t, ActionNone, rule.Evaluate(covered, now))
+}
+
+func TestRuleEvaluateDeleteMarkerWithoutExpiredObjectDeleteMarkerNeverExpires(t *testing.T) {
+	rule := Rule{
+		Status:     StatusEnabled,
+		Expiration: &Expiration{Days: 1},
+	}
+	marker := ObjectSummary{IsCurrent: true, IsDeleteMark: true, Created: time.Now().Add(-365 * 24 * time.Hour)}
+	require.Equal(t, ActionNone, rule.Evaluate(marker, time.Now()))
+}
+
+func TestFilterMatchesPrefixAndSize(t *testing.T) {
+	greaterThan := uint64(100)
+	f := Filter{Prefix: "logs/", ObjectSizeGreaterThan: &greaterThan}
+
+	require.True(t, f.Matches(ObjectSummary{Name: "logs/a.txt", Size: 200}))
+	require.False(t, f.Matches(ObjectSummary{Name: "logs/a.txt", Size: 50}))
+	require.False(t, f.Matches(ObjectSummary{Name: "other/a.txt", Size: 200}))
+}
+
+func TestFilterMatchesTags(t *testing.T) {
+	f := Filter{Tags: map[string]string{"archive": "true"}}
+
+	require.True(t, f.Matches(ObjectSummary{Tags: map[string]string{"archive": "true", "env": "prod"}}))
+	require.False(t, f.Matches(ObjectSummary{Tags: map[string]string{"archive": "false"}}))
+}
+
+func TestRuleEvaluateMultipartUploadAbort(t *testing.T) {
+	rule := Rule{
+		Status:                         StatusEnabled,
+		Filter:                         Filter{Prefix: "uploads/"},
+		AbortIncompleteMultipartUpload: &AbortIncompleteMultipartUpload{DaysAfterInitiation: 3},
+	}
+	now := time.Now()
+
+	stale := MultipartUploadSummary{Key: "uploads/big.bin", Initiated: now.Add(-4 * 24 * time.Hour)}
+	require.Equal(t, ActionAbortIncompleteUpload, rule.EvaluateMultipartUpload(stale, now))
+
+	fresh := MultipartUploadSummary{Key: "uploads/big.bin", Initiated: now.Add(-1 * time.Hour)}
+	require.Equal(t, ActionNone, rule.EvaluateMultipartUpload(fresh, now))
+
+	wrongPrefix := MultipartUploadSummary{Key: "other/big.bin", Initiated: now.Add(-4 * 24 * time.Hour)}
+	require.Equal(t, ActionNone, rule.EvaluateMultipartUpload(wrongPrefix, now))
+}
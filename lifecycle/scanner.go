@@ -0,0 +1,202 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultScanInterval is used when Config.Interval is zero.
+const defaultScanInterval = time.Hour
+
+// defaultLeaseTTL bounds how long a replica holds the scan lease for a
+// bucket before another replica is allowed to take over (e.g. after a crash).
+const defaultLeaseTTL = 5 * time.Minute
+
+// BucketSource enumerates buckets and their lifecycle configuration.
+type BucketSource interface {
+	ListBuckets(ctx context.Context) ([]string, error)
+	GetConfiguration(ctx context.Context, bucket string) (*Configuration, error)
+}
+
+// ObjectSource enumerates the objects and incomplete multipart uploads a
+// bucket's rules are evaluated against.
+type ObjectSource interface {
+	ListObjects(ctx context.Context, bucket string) ([]ObjectSummary, error)
+	ListIncompleteMultipartUploads(ctx context.Context, bucket string) ([]MultipartUploadSummary, error)
+}
+
+// ObjectSink performs the actions a matched rule requests.
+type ObjectSink interface {
+	ExpireObject(ctx context.Context, bucket string, obj ObjectSummary) error
+	AbortMultipartUpload(ctx context.Context, bucket string, upload MultipartUploadSummary) error
+}
+
+// Config controls the scanner's cadence and whether it actually performs
+// expirations or only reports what it would do.
+type Config struct {
+	// Interval between full passes over all buckets. Defaults to 1 hour.
+	Interval time.Duration
+	// LeaseTTL bounds how long this replica holds a bucket's scan lease.
+	// Defaults to 5 minutes.
+	LeaseTTL time.Duration
+	// DryRun, when true, evaluates rules and records metrics/logs without
+	// calling ObjectSink.
+	DryRun bool
+}
+
+// Scanner periodically walks every bucket, evaluates its lifecycle rules
+// against object metadata, and expires what matches. Only one gateway
+// replica acts on a given bucket at a time, coordinated via LeaseStore.
+type Scanner struct {
+	buckets BucketSource
+	objects ObjectSource
+	sink    ObjectSink
+	leases  LeaseStore
+	metrics *Metrics
+	log     *zap.Logger
+	owner   string
+	cfg     Config
+}
+
+// NewScanner builds a Scanner. owner identifies this gateway replica for
+// lease ownership (e.g. a hostname or pod name).
+func NewScanner(buckets BucketSource, objects ObjectSource, sink ObjectSink, leases LeaseStore, metrics *Metrics, owner string, cfg Config, log *zap.Logger) *Scanner {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultScanInterval
+	}
+	if cfg.LeaseTTL <= 0 {
+		cfg.LeaseTTL = defaultLeaseTTL
+	}
+
+	return &Scanner{
+		buckets: buckets,
+		objects: objects,
+		sink:    sink,
+		leases:  leases,
+		metrics: metrics,
+		log:     log,
+		owner:   owner,
+		cfg:     cfg,
+	}
+}
+
+// Run blocks, scanning every bucket once per Interval, until ctx is canceled.
+func (s *Scanner) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	s.scanAll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.scanAll(ctx)
+		}
+	}
+}
+
+func (s *Scanner) scanAll(ctx context.Context) {
+	buckets, err := s.buckets.ListBuckets(ctx)
+	if err != nil {
+		s.log.Error("lifecycle: could not list buckets", zap.Error(err))
+		return
+	}
+
+	for _, bucket := range buckets {
+		if err := s.scanBucket(ctx, bucket); err != nil {
+			s.log.Error("lifecycle: bucket scan failed", zap.String("bucket", bucket), zap.Error(err))
+			s.metrics.observeScan(bucket, "error")
+		}
+	}
+}
+
+func (s *Scanner) scanBucket(ctx context.Context, bucket string) error {
+	acquired, err := s.leases.TryAcquire(ctx, leaseKey(bucket), s.owner, s.cfg.LeaseTTL)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		s.metrics.observeScan(bucket, "skipped_leased")
+		return nil
+	}
+	defer func() {
+		if err := s.leases.Release(ctx, leaseKey(bucket), s.owner); err != nil {
+			s.log.Warn("lifecycle: could not release scan lease", zap.String("bucket", bucket), zap.Error(err))
+		}
+	}()
+
+	cfg, err := s.buckets.GetConfiguration(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if cfg == nil || len(cfg.Rules) == 0 {
+		s.metrics.observeScan(bucket, "no_rules")
+		return nil
+	}
+
+	now := time.Now()
+
+	objs, err := s.objects.ListObjects(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		s.applyRules(ctx, bucket, cfg.Rules, obj, now)
+	}
+
+	uploads, err := s.objects.ListIncompleteMultipartUploads(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	for _, upload := range uploads {
+		s.applyMultipartRules(ctx, bucket, cfg.Rules, upload, now)
+	}
+
+	s.metrics.observeScan(bucket, "ok")
+	return nil
+}
+
+func (s *Scanner) applyRules(ctx context.Context, bucket string, rules []Rule, obj ObjectSummary, now time.Time) {
+	for _, rule := range rules {
+		action := rule.Evaluate(obj, now)
+		if action == ActionNone {
+			continue
+		}
+
+		s.metrics.observeAction(bucket, rule.ID, action, s.cfg.DryRun)
+		if s.cfg.DryRun {
+			s.log.Info("lifecycle: dry-run would expire object",
+				zap.String("bucket", bucket), zap.String("object", obj.Name), zap.String("rule", rule.ID), zap.String("action", string(action)))
+			continue
+		}
+
+		if err := s.sink.ExpireObject(ctx, bucket, obj); err != nil {
+			s.log.Error("lifecycle: could not expire object",
+				zap.String("bucket", bucket), zap.String("object", obj.Name), zap.Error(err))
+		}
+	}
+}
+
+func (s *Scanner) applyMultipartRules(ctx context.Context, bucket string, rules []Rule, upload MultipartUploadSummary, now time.Time) {
+	for _, rule := range rules {
+		action := rule.EvaluateMultipartUpload(upload, now)
+		if action == ActionNone {
+			continue
+		}
+
+		s.metrics.observeAction(bucket, rule.ID, action, s.cfg.DryRun)
+		if s.cfg.DryRun {
+			s.log.Info("lifecycle: dry-run would abort multipart upload",
+				zap.String("bucket", bucket), zap.String("key", upload.Key), zap.String("rule", rule.ID))
+			continue
+		}
+
+		if err := s.sink.AbortMultipartUpload(ctx, bucket, upload); err != nil {
+			s.log.Error("lifecycle: could not abort multipart upload",
+				zap.String("bucket", bucket), zap.String("key", upload.Key), zap.Error(err))
+		}
+	}
+}
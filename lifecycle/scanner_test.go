@@ -0,0 +1,133 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeBucketSource struct {
+	buckets []string
+	cfgs    map[string]*Configuration
+}
+
+func (f *fakeBucketSource) ListBuckets(context.Context) ([]string, error) { return f.buckets, nil }
+func (f *fakeBucketSource) GetConfiguration(_ context.Context, bucket string) (*Configuration, error) {
+	return f.cfgs[bucket], nil
+}
+
+type fakeObjectSource struct {
+	objects map[string][]ObjectSummary
+}
+
+func (f *fakeObjectSource) ListObjects(_ context.Context, bucket string) ([]ObjectSummary, error) {
+	return f.objects[bucket], nil
+}
+func (f *fakeObjectSource) ListIncompleteMultipartUploads(context.Context, string) ([]MultipartUploadSummary, error) {
+	return nil, nil
+}
+
+type fakeSink struct {
+	mu      sync.Mutex
+	expired []string
+}
+
+func (f *fakeSink) ExpireObject(_ context.Context, bucket string, obj ObjectSummary) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.expired = append(f.expired, bucket+"/"+obj.Name)
+	return nil
+}
+func (f *fakeSink) AbortMultipartUpload(context.Context, string, MultipartUploadSummary) error {
+	return nil
+}
+
+type fakeLeaseStore struct {
+	held map[string]string
+}
+
+func newFakeLeaseStore() *fakeLeaseStore { return &fakeLeaseStore{held: make(map[string]string)} }
+
+func (f *fakeLeaseStore) TryAcquire(_ context.Context, key, owner string, _ time.Duration) (bool, error) {
+	if existing, ok := f.held[key]; ok && existing != owner {
+		return false, nil
+	}
+	f.held[key] = owner
+	return true, nil
+}
+
+func (f *fakeLeaseStore) Release(_ context.Context, key, owner string) error {
+	if f.held[key] == owner {
+		delete(f.held, key)
+	}
+	return nil
+}
+
+func TestScannerExpiresMatchingObjects(t *testing.T) {
+	now := time.Now()
+	buckets := &fakeBucketSource{
+		buckets: []string{"bucket1"},
+		cfgs: map[string]*Configuration{
+			"bucket1": {Rules: []Rule{{ID: "r1", Status: StatusEnabled, Expiration: &Expiration{Days: 1}}}},
+		},
+	}
+	objects := &fakeObjectSource{
+		objects: map[string][]ObjectSummary{
+			"bucket1": {
+				{Name: "old.txt", IsCurrent: true, Created: now.Add(-48 * time.Hour)},
+				{Name: "new.txt", IsCurrent: true, Created: now.Add(-1 * time.Hour)},
+			},
+		},
+	}
+	sink := &fakeSink{}
+
+	s := NewScanner(buckets, objects, sink, newFakeLeaseStore(), NewMetrics(), "replica-1", Config{}, zap.NewNop())
+	defer s.metrics.Unregister()
+
+	s.scanBucket(context.Background(), "bucket1")
+
+	require.Equal(t, []string{"bucket1/old.txt"}, sink.expired)
+}
+
+func TestScannerDryRunDoesNotExpire(t *testing.T) {
+	now := time.Now()
+	buckets := &fakeBucketSource{
+		buckets: []string{"bucket1"},
+		cfgs: map[string]*Configuration{
+			"bucket1": {Rules: []Rule{{ID: "r1", Status: StatusEnabled, Expiration: &Expiration{Days: 1}}}},
+		},
+	}
+	objects := &fakeObjectSource{
+		objects: map[string][]ObjectSummary{
+			"bucket1": {{Name: "old.txt", IsCurrent: true, Created: now.Add(-48 * time.Hour)}},
+		},
+	}
+	sink := &fakeSink{}
+
+	s := NewScanner(buckets, objects, sink, newFakeLeaseStore(), NewMetrics(), "replica-1", Config{DryRun: true}, zap.NewNop())
+	defer s.metrics.Unregister()
+
+	s.scanBucket(context.Background(), "bucket1")
+
+	require.Empty(t, sink.expired)
+}
+
+func TestScannerSkipsBucketHeldByAnotherReplica(t *testing.T) {
+	leases := newFakeLeaseStore()
+	_, err := leases.TryAcquire(context.Background(), leaseKey("bucket1"), "other-replica", time.Minute)
+	require.NoError(t, err)
+
+	buckets := &fakeBucketSource{buckets: []string{"bucket1"}, cfgs: map[string]*Configuration{}}
+	objects := &fakeObjectSource{objects: map[string][]ObjectSummary{}}
+	sink := &fakeSink{}
+
+	s := NewScanner(buckets, objects, sink, leases, NewMetrics(), "replica-1", Config{}, zap.NewNop())
+	defer s.metrics.Unregister()
+
+	require.NoError(t, s.scanBucket(context.Background(), "bucket1"))
+	require.Empty(t, sink.expired)
+}
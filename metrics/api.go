@@ -0,0 +1,108 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const apiSubsystem = "api"
+
+// defaultDurationBuckets is used when Config.DurationBuckets is empty.
+var defaultDurationBuckets = prometheus.DefBuckets
+
+// Config configures the API metrics, in particular the request-duration
+// histogram buckets, which depend on the gateway's expected latency profile.
+type Config struct {
+	// DurationBuckets overrides the default Prometheus histogram buckets
+	// for s3_request_duration_seconds. Leave nil to use prometheus.DefBuckets.
+	DurationBuckets []float64
+}
+
+// apiMetrics exposes per-API-operation request counters, latency histograms
+// and transferred-byte counters, alongside auth outcome counters.
+type apiMetrics struct {
+	requestsTotal    *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	requestBytes     *prometheus.CounterVec
+	inflightRequests *prometheus.GaugeVec
+	authTotal        *prometheus.CounterVec
+}
+
+func newAPIMetrics(cfg Config) *apiMetrics {
+	buckets := cfg.DurationBuckets
+	if len(buckets) == 0 {
+		buckets = defaultDurationBuckets
+	}
+
+	return &apiMetrics{
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: apiSubsystem,
+			Name:      "s3_requests_total",
+			Help:      "Total number of S3 API requests.",
+		}, []string{"api", "method", "status"}),
+
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: apiSubsystem,
+			Name:      "s3_request_duration_seconds",
+			Help:      "Duration of S3 API requests.",
+			Buckets:   buckets,
+		}, []string{"api"}),
+
+		requestBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: apiSubsystem,
+			Name:      "s3_request_bytes_total",
+			Help:      "Total number of bytes transferred by S3 API requests.",
+		}, []string{"api", "direction"}),
+
+		inflightRequests: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: apiSubsystem,
+			Name:      "s3_inflight_requests",
+			Help:      "Number of S3 API requests currently being served.",
+		}, []string{"api"}),
+
+		authTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: apiSubsystem,
+			Name:      "s3_auth_total",
+			Help:      "Total number of authentication outcomes, by result.",
+		}, []string{"result"}),
+	}
+}
+
+func (m *apiMetrics) register() {
+	prometheus.MustRegister(m.requestsTotal, m.requestDuration, m.requestBytes, m.inflightRequests, m.authTotal)
+}
+
+func (m *apiMetrics) unregister() {
+	prometheus.Unregister(m.requestsTotal)
+	prometheus.Unregister(m.requestDuration)
+	prometheus.Unregister(m.requestBytes)
+	prometheus.Unregister(m.inflightRequests)
+	prometheus.Unregister(m.authTotal)
+}
+
+// direction label values for s3_request_bytes_total.
+const (
+	directionIn  = "in"
+	directionOut = "out"
+)
+
+func (m *apiMetrics) observeRequestBytes(api string, n int) {
+	if n <= 0 {
+		return
+	}
+	m.requestBytes.WithLabelValues(api, directionIn).Add(float64(n))
+}
+
+func (m *apiMetrics) observeResponseBytes(api string, n int) {
+	if n <= 0 {
+		return
+	}
+	m.requestBytes.WithLabelValues(api, directionOut).Add(float64(n))
+}
+
+// observeAuth records an authentication outcome, e.g. "success" or "denied".
+func (m *apiMetrics) observeAuth(result string) {
+	m.authTotal.WithLabelValues(result).Inc()
+}
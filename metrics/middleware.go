@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// APIClassifier maps an incoming request to the S3 API operation name it
+// will be dispatched to (e.g. "PutObject", "CopyObject"), using the same
+// routing table the handler dispatcher itself relies on.
+type APIClassifier func(r *http.Request) string
+
+// APIMetrics is the handle returned by NewAPIMetrics: it registers the
+// Prometheus collectors and can build a middleware for the mux router.
+type APIMetrics struct {
+	m *apiMetrics
+}
+
+// NewAPIMetrics builds and registers the per-API-operation metrics described
+// by cfg.
+func NewAPIMetrics(cfg Config) *APIMetrics {
+	m := newAPIMetrics(cfg)
+	m.register()
+	return &APIMetrics{m: m}
+}
+
+// Unregister removes the metrics from the default Prometheus registry.
+func (a *APIMetrics) Unregister() {
+	a.m.unregister()
+}
+
+// Middleware returns a mux.MiddlewareFunc that classifies each request via
+// classify, times it, and records request/response byte counts. Install it
+// next to api.AttachUserAuth so every S3 API call is measured.
+func (a *APIMetrics) Middleware(classify APIClassifier) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiName := classify(r)
+
+			a.m.inflightRequests.WithLabelValues(apiName).Inc()
+			defer a.m.inflightRequests.WithLabelValues(apiName).Dec()
+
+			cr := &countingReadCloser{ReadCloser: r.Body}
+			r.Body = cr
+
+			cw := &countingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+
+			start := time.Now()
+			h.ServeHTTP(cw, r)
+			duration := time.Since(start)
+
+			a.m.requestDuration.WithLabelValues(apiName).Observe(duration.Seconds())
+			a.m.requestsTotal.WithLabelValues(apiName, r.Method, statusClass(cw.status)).Inc()
+			a.m.observeRequestBytes(apiName, cr.n)
+			a.m.observeResponseBytes(apiName, cw.n)
+		})
+	}
+}
+
+// ObserveAuth records an authentication outcome (e.g. "success", "denied").
+func (a *APIMetrics) ObserveAuth(result string) {
+	a.m.observeAuth(result)
+}
+
+func statusClass(status int) string {
+	switch {
+	case status >= 200 && status < 300:
+		return "2xx"
+	case status >= 300 && status < 400:
+		return "3xx"
+	case status >= 400 && status < 500:
+		return "4xx"
+	case status >= 500:
+		return "5xx"
+	default:
+		return "unknown"
+	}
+}
+
+// countingReadCloser counts bytes read from the request body.
+type countingReadCloser struct {
+	io.ReadCloser
+	n int
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += n
+	return n, err
+}
+
+// countingResponseWriter counts bytes written to the response and captures
+// the status code passed to WriteHeader.
+type countingResponseWriter struct {
+	http.ResponseWriter
+	status int
+	n      int
+}
+
+func (c *countingResponseWriter) WriteHeader(status int) {
+	c.status = status
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := c.ResponseWriter.Write(p)
+	c.n += n
+	return n, err
+}
+
+// Flush forwards to the underlying ResponseWriter's http.Flusher, if it has
+// one, so handlers that stream (e.g. SelectObjectContent) keep working when
+// wrapped by this middleware.
+func (c *countingResponseWriter) Flush() {
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusClass(t *testing.T) {
+	require.Equal(t, "2xx", statusClass(200))
+	require.Equal(t, "3xx", statusClass(301))
+	require.Equal(t, "4xx", statusClass(404))
+	require.Equal(t, "5xx", statusClass(500))
+	require.Equal(t, "unknown", statusClass(0))
+}
+
+func TestAPIMetricsMiddlewareRecordsRequest(t *testing.T) {
+	m := NewAPIMetrics(Config{})
+	defer m.Unregister()
+
+	handler := m.Middleware(func(*http.Request) string { return "PutObject" })(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("ok"))
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodPut, "/bucket/key", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var recorded dto.Metric
+	require.NoError(t, m.m.requestsTotal.WithLabelValues("PutObject", http.MethodPut, "2xx").Write(&recorded))
+	require.Equal(t, float64(1), recorded.GetCounter().GetValue())
+}
+
+func TestAPIMetricsMiddlewarePreservesFlusher(t *testing.T) {
+	m := NewAPIMetrics(Config{})
+	defer m.Unregister()
+
+	var sawFlusher bool
+	handler := m.Middleware(func(*http.Request) string { return "SelectObjectContent" })(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, sawFlusher = w.(http.Flusher)
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/bucket/key", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.True(t, sawFlusher, "countingResponseWriter must still satisfy http.Flusher")
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// Server exposes the registered collectors on /metrics over a listener that
+// is separate from the public S3 endpoint, so scraping never competes with
+// user traffic on the same port.
+type Server struct {
+	http *http.Server
+	log  *zap.Logger
+}
+
+// NewServer builds a metrics HTTP server bound to addr, serving /metrics via
+// the default Prometheus registry.
+func NewServer(addr string, log *zap.Logger) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return &Server{
+		http: &http.Server{Addr: addr, Handler: mux},
+		log:  log,
+	}
+}
+
+// Start runs the metrics server until the process exits or Shutdown is called.
+// It's meant to be run in its own goroutine, mirroring how the gateway runs
+// its main S3 listener.
+func (s *Server) Start() {
+	if err := s.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		s.log.Error("metrics server stopped", zap.Error(err))
+	}
+}
+
+// Shutdown gracefully stops the metrics server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.http.Shutdown(ctx)
+}